@@ -4,24 +4,24 @@ package czml
 // by the position property. A billboard is sometimes called a marker.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Billboard
 type Billboard struct {
-	Show                       *bool                     `json:"show,omitempty"`
-	Image                      string                    `json:"image"`
-	Scale                      *float64                  `json:"scale,omitempty"`
-	PixelOffset                *PixelOffset              `json:"pixelOffset,omitempty"`
-	EyeOffset                  *EyeOffset                `json:"eyeOffset,omitempty"`
-	HorizontalOrigin           *HorizontalOrigin         `json:"horizontalOrigin,omitempty"`
-	VerticalOrigin             *VerticalOrigin           `json:"verticalOrigin,omitempty"`
-	HeightReference            *HeightReference          `json:"heightReference,omitempty"`
-	Color                      *Color                    `json:"color,omitempty"`
-	Rotation                   *float64                  `json:"rotation,omitempty"`
-	AlignedAxis                *AlignedAxis              `json:"alignedAxis,omitempty"`
-	SizeInMeters               *bool                     `json:"sizeInMeters,omitempty"`
-	Width                      *float64                  `json:"width,omitempty"`
-	Height                     *float64                  `json:"height,omitempty"`
-	ScaleByDistance            *NearFarScaler            `json:"scaleByDistance,omitempty"`
-	TranslucencyByDistance     *NearFarScaler            `json:"translucencyByDistance,omitempty"`
-	PixelOffsetScaleByDistance *NearFarScaler            `json:"pixelOffsetScaleByDistance,omitempty"`
-	ImageSubRegion             *BoundingRectangle        `json:"imageSubRegion,omitempty"`
-	DistanceDisplayCondition   *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	DisableDepthTestDistance   *float64                  `json:"disableDepthTestDistance,omitempty"`
+	Show                       *Property[bool]                     `json:"show,omitempty"`
+	Image                      *Property[UriValue]                 `json:"image,omitempty"`
+	Scale                      *Property[Double]                   `json:"scale,omitempty"`
+	PixelOffset                *Property[PixelOffset]              `json:"pixelOffset,omitempty"`
+	EyeOffset                  *Property[EyeOffset]                `json:"eyeOffset,omitempty"`
+	HorizontalOrigin           *Property[HorizontalOrigin]         `json:"horizontalOrigin,omitempty"`
+	VerticalOrigin             *Property[VerticalOrigin]           `json:"verticalOrigin,omitempty"`
+	HeightReference            *Property[HeightReference]          `json:"heightReference,omitempty"`
+	Color                      *Property[Color]                    `json:"color,omitempty"`
+	Rotation                   *Property[Double]                   `json:"rotation,omitempty"`
+	AlignedAxis                *Property[AlignedAxis]              `json:"alignedAxis,omitempty"`
+	SizeInMeters               *Property[bool]                     `json:"sizeInMeters,omitempty"`
+	Width                      *Property[Double]                   `json:"width,omitempty"`
+	Height                     *Property[Double]                   `json:"height,omitempty"`
+	ScaleByDistance            *Property[NearFarScaler]            `json:"scaleByDistance,omitempty"`
+	TranslucencyByDistance     *Property[NearFarScaler]            `json:"translucencyByDistance,omitempty"`
+	PixelOffsetScaleByDistance *Property[NearFarScaler]            `json:"pixelOffsetScaleByDistance,omitempty"`
+	ImageSubRegion             *Property[BoundingRectangle]        `json:"imageSubRegion,omitempty"`
+	DistanceDisplayCondition   *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	DisableDepthTestDistance   *Property[Double]                   `json:"disableDepthTestDistance,omitempty"`
 }
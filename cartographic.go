@@ -7,10 +7,7 @@ package czml
 // Time is an ISO 8601 date and time string or seconds since epoch
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicDegreesValue
 type CartographicDegreesValue struct {
-	Lat    float64
-	Lon    float64
-	Height float64
-	Time   string
+	SampledValue
 }
 
 // CartographicRadiansValue is a geodetic, WGS84 position specified as [Longitude, Latitude, Height]
@@ -19,19 +16,21 @@ type CartographicDegreesValue struct {
 // arranged as [Time, Longitude, Latitude, Height, Time, Longitude, Latitude, Height, ...], where
 // Time is an ISO 8601 date and time string or seconds since epoch
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicRadiansValue
-type CartographicRadiansValue []float64
+type CartographicRadiansValue struct {
+	SampledValue
+}
 
 // CartographicRadiansListOfListsValue is a list of lists of geodetic, WGS84 positions specified as
 // [Longitude, Latitude, Height, Longitude, Latitude, Height, ...], where Longitude and Latitude are
 // in radians and Height is in meters.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicRadiansListOfListsValue
-type CartographicRadiansListOfListsValue []CartographicRadiansValue
+type CartographicRadiansListOfListsValue []CartographicRadiansListValue
 
 // CartographicDegreesListOfListsValue is a list of lists of geodetic, WGS84 positions specified as
 // [Longitude, Latitude, Height, Longitude, Latitude, Height, ...], where Longitude and Latitude are
 // in degrees and Height is in meters.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicDegreesListOfListsValue
-type CartographicDegreesListOfListsValue []CartographicDegreesValue
+type CartographicDegreesListOfListsValue []CartographicDegreesListValue
 
 // CartographicRectangleRadiansValue is a two-dimensional region specified as [WestLongitude,
 // SouthLatitude, EastLongitude, NorthLatitude], with values in radians. If the array has four
@@ -40,7 +39,9 @@ type CartographicDegreesListOfListsValue []CartographicDegreesValue
 // WestLongitude, SouthLatitude, EastLongitude, NorthLatitude, ...], where Time is an ISO 8601 date
 // and time string or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicRectangleRadiansValue
-type CartographicRectangleRadiansValue []interface{}
+type CartographicRectangleRadiansValue struct {
+	SampledValue
+}
 
 // CartographicRectangleDegreesValue is a two-dimensional region specified as [WestLongitude,
 // SouthLatitude, EastLongitude, NorthLatitude], with values in degrees. If the array has four
@@ -49,10 +50,18 @@ type CartographicRectangleRadiansValue []interface{}
 // WestLongitude, SouthLatitude, EastLongitude, NorthLatitude, ...], where Time is an ISO 8601 date
 // and time string or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicRectangleDegreesValue
-type CartographicRectangleDegreesValue []interface{}
+type CartographicRectangleDegreesValue struct {
+	SampledValue
+}
 
 // CartographicRadiansListValue is a list of geodetic, WGS84 positions specified as
 // [Longitude, Latitude, Height, Longitude, Latitude, Height, ...], where Longitude and Latitude
 // are in radians and Height is in meters.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicRadiansListValue
 type CartographicRadiansListValue []float64
+
+// CartographicDegreesListValue is a list of geodetic, WGS84 positions specified as
+// [Longitude, Latitude, Height, Longitude, Latitude, Height, ...], where Longitude and Latitude
+// are in degrees and Height is in meters.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CartographicDegreesListValue
+type CartographicDegreesListValue []float64
@@ -3,8 +3,9 @@ package czml
 // Color describes a color. The color can optionally vary over time
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Color
 type Color struct {
-	Rgba      RgbaValue      `json:"rgba,omitempty"`
-	Rgbaf     RgbafValue     `json:"rgbaf,omitempty"`
+	Interpolatable
+	Rgba      *RgbaValue     `json:"rgba,omitempty"`
+	Rgbaf     *RgbafValue    `json:"rgbaf,omitempty"`
 	Reference ReferenceValue `json:"reference,omitempty"`
 }
 
@@ -14,7 +15,9 @@ type Color struct {
 // Alpha, Time, Red, Green, Blue, Alpha, ...], where Time is an ISO 8601 date and time string or
 // seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/RgbaValue
-type RgbaValue []int
+type RgbaValue struct {
+	SampledValue
+}
 
 // RgbafValue is a color specified as an array of color components [Red, Green, Blue, Alpha] where
 // each component is in the range 0.0-1.0. If the array has four elements, the color is constant.
@@ -22,9 +25,11 @@ type RgbaValue []int
 // Blue, Alpha, Time, Red, Green, Blue, Alpha, ...], where Time is an ISO 8601 date and time string
 // or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/RgbafValue
-type RgbafValue []float32
+type RgbafValue struct {
+	SampledValue
+}
 
-func translateColor(color string) (rgba RgbaValue) {
+func translateColor(color string) (rgba []int) {
 	switch color {
 	case "red":
 		rgba = []int{255, 0, 0, 255}
@@ -46,3 +51,13 @@ func translateColor(color string) (rgba RgbaValue) {
 
 	return rgba
 }
+
+// rgbaValue returns a constant RgbaValue from components in the range 0-255
+func rgbaValue(rgba []int) *RgbaValue {
+	v := RgbaValue{}
+	for _, c := range rgba {
+		v.Value = append(v.Value, float64(c))
+	}
+
+	return &v
+}
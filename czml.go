@@ -10,12 +10,35 @@ type Czml struct {
 	Packets []Packet
 }
 
+// MarshalJSON encodes the Czml as the bare array of packets found in a .czml file
+func (c Czml) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Packets)
+}
+
+// UnmarshalJSON decodes a .czml array of packets into the Czml
+func (c *Czml) UnmarshalJSON(data []byte) error {
+	var packets []Packet
+	if err := json.Unmarshal(data, &packets); err != nil {
+		return err
+	}
+
+	c.Packets = packets
+	return nil
+}
+
 // Unmarshal accepts raw byte data (i.e. a .czml file) and returns
 // the data Unmarshaled into the CZML structured interface
 func Unmarshal(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
 
+// Parse accepts raw byte data (i.e. a .czml file) and returns it as a Czml
+func Parse(data []byte) (Czml, error) {
+	var c Czml
+	err := json.Unmarshal(data, &c)
+	return c, err
+}
+
 // Marshal accepts a name for the document and a Packet array,
 // and returns the Marshaled czml data
 func Marshal(c Czml) ([]byte, error) {
@@ -43,14 +66,15 @@ func (c *Czml) AddPacket(p Packet) {
 	c.Packets = append(c.Packets, p)
 }
 
-func (c *Czml) AddClock(interval, currentTime string, multiplier float64) error {
+// AddClock sets the Clock of the "document" packet, which must already be initialized
+func (c *Czml) AddClock(interval TimeInterval, currentTime JulianDate, multiplier float64) error {
 	if len(c.Packets) == 0 || c.Packets[0].Id != "document" {
 		return errors.New("initialize document before adding properties")
 	}
 
 	c.Packets[0].Clock = &Clock{
-		Interval:    interval,
-		CurrentTime: currentTime,
+		Interval:    &interval,
+		CurrentTime: &currentTime,
 		Multiplier:  &multiplier,
 	}
 
@@ -0,0 +1,63 @@
+package czml
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMarshalParseRoundTrip(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var clocked Czml
+	clocked.InitializeDocument("clocked")
+	if err := clocked.AddClock(NewTimeInterval(start, start.Add(time.Hour)), NewJulianDate(start), 60); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		czml Czml
+	}{
+		{"empty", Czml{}},
+		{"document with a clock", clocked},
+		{"packets", Czml{Packets: append([]Packet{{Id: "document", Name: "packets", Version: "1.0"}}, parsePackets(t, []string{
+			`{"id": "b", "name": "Billboard", "billboard": {"image": "pin.png", "scale": 2, "color": {"rgba": [255, 0, 0, 255]}}}`,
+			`{"id": "p", "availability": "2020-01-01T00:00:00Z/2020-01-01T01:00:00Z",
+				"position": {"epoch": "2020-01-01T00:00:00Z", "cartographicDegrees": [0, 1, 2, 3, 60, 4, 5, 6]}}`,
+			`{"id": "i", "parent": "b", "label": {"text": [
+				{"interval": "2020-01-01T00:00:00Z/2020-01-01T00:30:00Z", "string": "a"},
+				{"interval": "2020-01-01T00:30:00Z/2020-01-01T01:00:00Z", "reference": "b#name"}]}}`,
+			`{"id": "c", "properties": {"kind": "stop", "rank": 1, "tags": {"array": ["a", "b"]}},
+				"polyline": {"positions": {"cartographicDegrees": [0, 0, 0, 1, 1, 0]}, "material": {"polylineDash": {"dashLength": 8}}}}`,
+			`{"id": "d", "delete": true}`,
+		})...)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := Marshal(tt.czml)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := Parse(first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := Marshal(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first, second) {
+				t.Errorf("written again as\n%s\nwant\n%s", second, first)
+			}
+
+			var unmarshaled Czml
+			if err := Unmarshal(first, &unmarshaled); err != nil {
+				t.Fatal(err)
+			}
+			if len(unmarshaled.Packets) != len(tt.czml.Packets) {
+				t.Errorf("unmarshaled %d packets, want %d", len(unmarshaled.Packets), len(tt.czml.Packets))
+			}
+		})
+	}
+}
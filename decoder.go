@@ -0,0 +1,154 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DecodeError describes a packet that could not be read from a .czml stream
+type DecodeError struct {
+	Index  int   // index of the packet in the array
+	Offset int64 // byte offset in the stream where the packet starts
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("czml: packet %d at byte offset %d: %v", e.Index, e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Decoder reads packets one at a time from a .czml stream, so that large files can be processed
+// without holding them in memory
+type Decoder struct {
+	dec   *json.Decoder
+	in    *recorder
+	end   int64 // byte offset of the end of the last value read
+	index int
+	state int
+}
+
+const (
+	decoderStart = iota
+	decoderPackets
+	decoderDone
+)
+
+// NewDecoder returns a Decoder that reads packets from r
+func NewDecoder(r io.Reader) *Decoder {
+	in := &recorder{r: r}
+	return &Decoder{dec: json.NewDecoder(in), in: in}
+}
+
+// Next returns the next Packet in the stream. The first packet must be the "document" packet.
+// Next returns io.EOF once the closing bracket of the packet array has been read, and an error if
+// anything but whitespace follows it.
+func (d *Decoder) Next() (Packet, error) {
+	var p Packet
+
+	switch d.state {
+	case decoderDone:
+		return p, io.EOF
+	case decoderStart:
+		if err := d.readOpen(); err != nil {
+			d.state = decoderDone
+			return p, err
+		}
+		d.state = decoderPackets
+	}
+
+	if !d.dec.More() {
+		d.state = decoderDone
+		if _, err := d.dec.Token(); err != nil {
+			return p, d.errorf(err)
+		}
+		if d.index == 0 {
+			return p, d.errorf(errors.New("missing document packet"))
+		}
+		d.end = d.dec.InputOffset()
+		if _, err := d.dec.Token(); err != io.EOF {
+			return p, d.errorf(errors.New("unexpected data after the packet array"))
+		}
+		return p, io.EOF
+	}
+
+	err := d.dec.Decode(&p)
+	offset := d.in.next(d.end)
+	if err != nil {
+		d.state = decoderDone
+		return p, &DecodeError{Index: d.index, Offset: offset, Err: err}
+	}
+	d.end = d.dec.InputOffset()
+	d.in.discard(d.end)
+
+	if d.index == 0 && p.Id != "document" {
+		d.state = decoderDone
+		return p, &DecodeError{Index: d.index, Offset: offset, Err: errors.New("first packet must be the document packet")}
+	}
+
+	d.index++
+	return p, nil
+}
+
+// readOpen consumes the opening bracket of the packet array
+func (d *Decoder) readOpen() error {
+	t, err := d.dec.Token()
+	if err == io.EOF {
+		return d.errorf(errors.New("empty stream"))
+	} else if err != nil {
+		return d.errorf(err)
+	}
+
+	if delim, ok := t.(json.Delim); !ok || delim != '[' {
+		return d.errorf(fmt.Errorf("expected an array of packets, found %v", t))
+	}
+
+	d.end = d.dec.InputOffset()
+	d.in.discard(d.end)
+	return nil
+}
+
+// errorf returns an error for the value following the last value read
+func (d *Decoder) errorf(err error) error {
+	return &DecodeError{Index: d.index, Offset: d.in.next(d.end), Err: err}
+}
+
+// recorder keeps the bytes read from a stream since the end of the last value, so that the offset
+// of the next value can be found whatever the json.Decoder has buffered
+type recorder struct {
+	r     io.Reader
+	start int64 // byte offset of the first byte kept
+	kept  []byte
+}
+
+func (r *recorder) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.kept = append(r.kept, p[:n]...)
+	return n, err
+}
+
+// discard drops the bytes before an offset
+func (r *recorder) discard(offset int64) {
+	n := int(offset - r.start)
+	r.kept = r.kept[:copy(r.kept, r.kept[n:])]
+	r.start = offset
+}
+
+// next returns the offset of the first byte from an offset that is not a separating comma or
+// whitespace
+func (r *recorder) next(offset int64) int64 {
+	for i := int(offset - r.start); i < len(r.kept); i++ {
+		switch r.kept[i] {
+		case ',', ' ', '\t', '\n', '\r':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
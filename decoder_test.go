@@ -0,0 +1,85 @@
+package czml
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecoder(t *testing.T) {
+	spaces := strings.Repeat(" ", 5000)
+
+	tests := []struct {
+		name   string
+		input  string
+		ids    []string // the ids of the packets read before the error or the end
+		index  int      // the index of the failing packet, or -1 when the stream ends cleanly
+		offset int64
+	}{
+		{"packets", `[{"id":"document","version":"1.0"},{"id":"a"},{"id":"b"}]`, []string{"document", "a", "b"}, -1, 0},
+		{"whitespace", " [ {\"id\":\"document\"} ,\n\t{\"id\":\"a\"}\r\n] \n", []string{"document", "a"}, -1, 0},
+		{"malformed packet", `[{"id":"document"}, {"id": 5}]`, []string{"document"}, 1, 20},
+		{"syntax error", `[{"id":"document"}, {"id": }]`, []string{"document"}, 1, 20},
+		{"missing comma", `[{"id":"document"} {"id":"a"}]`, []string{"document"}, 1, 19},
+		{"malformed packet after long whitespace", `[{"id":"document"},` + spaces + `{"id": 5}]`, []string{"document"}, 1, 5019},
+		{"syntax error after long whitespace", `[{"id":"document"},` + spaces + `{"id": }]`, []string{"document"}, 1, 5019},
+		{"document not first", `[{"id":"a"},{"id":"document"}]`, nil, 0, 1},
+		{"missing document", `[]`, nil, 0, 1},
+		{"empty stream", ``, nil, 0, 0},
+		{"not an array", `{"id":"document"}`, nil, 0, 0},
+		{"unclosed array", `[{"id":"document"}`, []string{"document"}, 1, 18},
+		{"trailing data", `[{"id":"document"}] x`, []string{"document"}, 1, 20},
+		{"trailing array", `[{"id":"document"}]` + "\n[]", []string{"document"}, 1, 20},
+	}
+
+	readers := []struct {
+		name string
+		wrap func(io.Reader) io.Reader
+	}{
+		{"whole", func(r io.Reader) io.Reader { return r }},
+		{"one byte at a time", iotest.OneByteReader},
+	}
+
+	for _, tt := range tests {
+		for _, r := range readers {
+			t.Run(tt.name+" "+r.name, func(t *testing.T) {
+				d := NewDecoder(r.wrap(strings.NewReader(tt.input)))
+
+				var ids []string
+				var err error
+				for {
+					var p Packet
+					if p, err = d.Next(); err != nil {
+						break
+					}
+					ids = append(ids, p.Id)
+				}
+				if strings.Join(ids, ",") != strings.Join(tt.ids, ",") {
+					t.Errorf("read %v, want %v", ids, tt.ids)
+				}
+
+				if tt.index < 0 {
+					if err != io.EOF {
+						t.Fatalf("error is %v, want io.EOF", err)
+					}
+				} else {
+					var de *DecodeError
+					if !errors.As(err, &de) {
+						t.Fatalf("error is %v, want a DecodeError", err)
+					}
+					if de.Index != tt.index || de.Offset != tt.offset {
+						t.Errorf("error is for packet %d at offset %d, want packet %d at offset %d: %v",
+							de.Index, de.Offset, tt.index, tt.offset, de)
+					}
+				}
+
+				// the decoder stops at the first error
+				if _, err := d.Next(); err != io.EOF {
+					t.Errorf("error after the end is %v, want io.EOF", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package czml
+
+import (
+	"reflect"
+	"time"
+)
+
+// Diff returns the packets that update old to new when applied in order with CZML merge rules, such
+// as by a Scene or by Cesium. Entities are compared by id, and each changed entity gets a packet
+// holding only its changed properties, with new samples and intervals in place of whole values.
+// Entities missing from new get a packet with "delete": true. Changes that merging cannot express,
+// such as a property being removed, are sent as a delete packet followed by the whole entity.
+// Packets without an id are ignored.
+func Diff(old, new Czml) []Packet {
+	before, after := NewScene(), NewScene()
+	for _, p := range old.Packets {
+		before.Apply(p)
+	}
+	for _, p := range new.Packets {
+		after.Apply(p)
+	}
+
+	var packets []Packet
+
+	if document, ok := after.Document(); ok {
+		previous, existed := before.Document()
+		update, changed, ok := diffPacket(previous, document)
+		switch {
+		case !existed, !ok:
+			// the document cannot be deleted, so it is sent whole
+			packets = append(packets, document)
+		case changed:
+			packets = append(packets, update)
+		}
+	}
+
+	for _, id := range before.order {
+		if _, ok := after.entities[id]; !ok {
+			packets = append(packets, deletePacket(id))
+		}
+	}
+
+	for _, id := range after.order {
+		entity, _ := after.Entity(id)
+		previous, existed := before.Entity(id)
+		if !existed {
+			packets = append(packets, entity)
+			continue
+		}
+
+		update, changed, ok := diffPacket(previous, entity)
+		switch {
+		case !ok:
+			packets = append(packets, deletePacket(id), entity)
+		case changed:
+			packets = append(packets, update)
+		}
+	}
+
+	return packets
+}
+
+func deletePacket(id string) Packet {
+	deleted := true
+	return Packet{Id: id, Delete: &deleted}
+}
+
+// diffPacket returns a packet holding the changes from old to new, whether there are any, and
+// whether merging can express them
+func diffPacket(old, new Packet) (Packet, bool, bool) {
+	update := Packet{Id: new.Id}
+	changed, ok := diff(reflect.ValueOf(&update).Elem(), reflect.ValueOf(&old).Elem(),
+		reflect.ValueOf(&new).Elem(), nil)
+
+	return update, changed, ok
+}
+
+// differ is implemented by values with their own rules for applying a later value, returning the
+// smallest value that merges old into the receiver, or false if there is none
+type differ interface {
+	diff(old interface{}) (interface{}, bool)
+}
+
+// diff sets out to the smallest value that merges old into new. It reports whether out was set, and
+// whether merging can turn old into new at all. epoch is the epoch of the samples in new.
+func diff(out, old, new reflect.Value, epoch *JulianDate) (bool, bool) {
+	switch new.Kind() {
+	case reflect.Ptr:
+		if new.IsNil() {
+			return false, old.IsNil()
+		}
+		if old.IsNil() {
+			out.Set(deepCopy(new))
+			return true, true
+		}
+		update := reflect.New(new.Type().Elem())
+		changed, ok := diff(update.Elem(), old.Elem(), new.Elem(), epoch)
+		if changed {
+			out.Set(update)
+		}
+		return changed, ok
+	case reflect.Struct:
+		return diffStruct(out, old, new, epoch)
+	case reflect.Map:
+		if new.IsNil() {
+			return false, old.Len() == 0
+		}
+		update := reflect.MakeMap(new.Type())
+		iter := old.MapRange()
+		for iter.Next() {
+			if !new.MapIndex(iter.Key()).IsValid() {
+				return false, false
+			}
+		}
+		iter = new.MapRange()
+		for iter.Next() {
+			previous := old.MapIndex(iter.Key())
+			if !previous.IsValid() || !reflect.DeepEqual(previous.Interface(), iter.Value().Interface()) {
+				update.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+		}
+		if update.Len() == 0 {
+			return false, true
+		}
+		out.Set(update)
+		return true, true
+	}
+
+	if reflect.DeepEqual(old.Interface(), new.Interface()) {
+		return false, true
+	}
+	if new.IsZero() {
+		return false, false
+	}
+	out.Set(deepCopy(new))
+	return true, true
+}
+
+func diffStruct(out, old, new reflect.Value, epoch *JulianDate) (bool, bool) {
+	if reflect.DeepEqual(old.Interface(), new.Interface()) {
+		return false, true
+	}
+
+	switch n := new.Addr().Interface().(type) {
+	case differ:
+		update, ok := n.diff(old.Addr().Interface())
+		if !ok {
+			return false, false
+		}
+		out.Set(reflect.ValueOf(update).Elem())
+		return true, true
+	case sampledValuer:
+		update, ok := n.sampled().diff(old.Addr().Interface().(sampledValuer).sampled(), epoch)
+		if !ok {
+			return false, false
+		}
+		*out.Addr().Interface().(sampledValuer).sampled() = update
+		return true, true
+	}
+
+	if reflect.PtrTo(new.Type()).Implements(marshalerType) {
+		if new.IsZero() {
+			return false, false
+		}
+		out.Set(deepCopy(new))
+		return true, true
+	}
+
+	t := new.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Anonymous && t.Field(i).Type == interpolatableType {
+			return diffInterpolatable(out, old, new, i)
+		}
+	}
+
+	changed := false
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			continue
+		}
+		c, ok := diff(out.Field(i), old.Field(i), new.Field(i), epoch)
+		if !ok {
+			return false, false
+		}
+		changed = changed || c
+	}
+
+	return changed, true
+}
+
+// diffInterpolatable diffs structs whose field k is their Interpolatable. Merging keeps the epoch of
+// the earlier value when its samples rely on it, and a value in any representation replaces the
+// others, so every representation of new is sent whenever one of them changes.
+func diffInterpolatable(out, old, new reflect.Value, k int) (bool, bool) {
+	oi := *old.Field(k).Addr().Interface().(*Interpolatable)
+	ni := *new.Field(k).Addr().Interface().(*Interpolatable)
+	epoch := ni.Epoch
+
+	epochChanged := !sameEpoch(oi.Epoch, ni.Epoch)
+	if epochChanged && (usesEpoch(old) || ni.Epoch == nil) {
+		return false, false
+	}
+
+	t := new.Type()
+	representationChanged := false
+	for j := 0; j < t.NumField(); j++ {
+		if isRepresentation(t.Field(j)) && !reflect.DeepEqual(old.Field(j).Interface(), new.Field(j).Interface()) {
+			representationChanged = true
+		}
+	}
+	if representationChanged && representations(new) == 0 {
+		return false, false
+	}
+
+	changed := false
+	for j := 0; j < t.NumField(); j++ {
+		f := t.Field(j)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		var c, ok bool
+		switch {
+		case j == k:
+			oi.Epoch, ni.Epoch = nil, nil
+			c, ok = diff(out.Field(k), reflect.ValueOf(&oi).Elem(), reflect.ValueOf(&ni).Elem(), nil)
+		case isRepresentation(f) && representationChanged && new.Field(j).IsZero():
+			// cleared by the representations that are sent
+			c, ok = false, true
+		case isRepresentation(f) && representationChanged &&
+			reflect.DeepEqual(old.Field(j).Interface(), new.Field(j).Interface()):
+			out.Field(j).Set(deepCopy(new.Field(j)))
+			c, ok = true, true
+		default:
+			c, ok = diff(out.Field(j), old.Field(j), new.Field(j), epoch)
+		}
+		if !ok {
+			return false, false
+		}
+		changed = changed || c
+	}
+
+	if epoch != nil && (epochChanged || usesEpoch(out)) {
+		out.Field(k).Addr().Interface().(*Interpolatable).Epoch = epoch
+		changed = true
+	}
+
+	return changed, true
+}
+
+// diff returns the samples of v that are missing from old or differ from it, or false if merging
+// samples into old cannot produce v. A constant v, or samples replacing a constant, are returned
+// whole.
+func (v *SampledValue) diff(old *SampledValue, epoch *JulianDate) (SampledValue, bool) {
+	if !v.IsSampled() {
+		if v.Value == nil {
+			return SampledValue{}, false
+		}
+		return deepCopy(reflect.ValueOf(*v)).Interface().(SampledValue), true
+	}
+	if !old.IsSampled() {
+		return deepCopy(reflect.ValueOf(*v)).Interface().(SampledValue), true
+	}
+
+	type instant struct {
+		seconds int64
+		nanos   int
+	}
+	key := func(t time.Time) instant { return instant{t.Unix(), t.Nanosecond()} }
+
+	index := map[instant]int{}
+	var previous time.Time
+	for i, s := range v.Samples {
+		t, err := s.Time.Resolve(epoch)
+		if err != nil || (i > 0 && !t.After(previous)) {
+			// merged samples are always in time order without repeated times
+			return SampledValue{}, false
+		}
+		index[key(t)] = i
+		previous = t
+	}
+
+	unchanged := make([]bool, len(v.Samples))
+	for _, s := range old.Samples {
+		t, err := s.Time.Resolve(epoch)
+		if err != nil {
+			return SampledValue{}, false
+		}
+		i, ok := index[key(t)]
+		if !ok {
+			return SampledValue{}, false
+		}
+		unchanged[i] = reflect.DeepEqual(s, v.Samples[i])
+	}
+
+	update := SampledValue{}
+	for i, s := range v.Samples {
+		if !unchanged[i] {
+			update.Samples = append(update.Samples, deepCopy(reflect.ValueOf(s)).Interface().(Sample))
+		}
+	}
+	if !update.IsSampled() {
+		return SampledValue{}, false
+	}
+
+	return update, true
+}
+
+// diff returns the property that merges old into p. Intervals are sent from the first one that old
+// does not end with, and a single value is sent whole, or diffed against an earlier single value
+// held in an object.
+func (p *Property[T]) diff(old interface{}) (interface{}, bool) {
+	o := old.(*Property[T])
+	if value := reflect.ValueOf(&p.Value).Elem(); value.Kind() == reflect.Struct &&
+		!p.HasIntervals() && !o.HasIntervals() && p.Reference == "" && o.Reference == "" {
+		var update Property[T]
+		if _, ok := diff(reflect.ValueOf(&update.Value).Elem(), reflect.ValueOf(&o.Value).Elem(), value, nil); !ok {
+			return nil, false
+		}
+		return &update, true
+	}
+	if !p.HasIntervals() || !o.HasIntervals() {
+		update := deepCopy(reflect.ValueOf(*p)).Interface().(Property[T])
+		return &update, true
+	}
+
+	for first := len(p.Intervals); first >= 0; first-- {
+		sent := p.Intervals[first:]
+		var kept []IntervalValue[T]
+		for _, existing := range o.Intervals {
+			replaced := false
+			for _, iv := range sent {
+				if existing.Interval.Start.Equal(iv.Interval.Start.Time) &&
+					existing.Interval.Stop.Equal(iv.Interval.Stop.Time) {
+					replaced = true
+				}
+			}
+			if !replaced {
+				kept = append(kept, existing)
+			}
+		}
+
+		if len(sent) > 0 && len(kept) == first && (first == 0 || reflect.DeepEqual(kept, p.Intervals[:first])) {
+			update := deepCopy(reflect.ValueOf(Property[T]{Intervals: sent})).Interface().(Property[T])
+			return &update, true
+		}
+	}
+
+	return nil, false
+}
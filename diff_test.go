@@ -0,0 +1,85 @@
+package czml
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	document := `{"id": "document", "version": "1.0"}`
+
+	tests := []struct {
+		name     string
+		old, new []string
+		packets  int // the number of packets Diff returns
+	}{
+		{"unchanged", []string{document, `{"id": "e", "name": "same"}`}, []string{document, `{"id": "e", "name": "same"}`}, 0},
+		{"added entity", []string{document}, []string{document, `{"id": "e", "name": "new"}`}, 1},
+		{"removed entity", []string{document, `{"id": "e"}`}, []string{document}, 1},
+		{"changed constant", []string{document, `{"id": "e", "name": "a", "label": {"text": "x"}}`},
+			[]string{document, `{"id": "e", "name": "a", "label": {"text": "y"}}`}, 1},
+		{"new samples", []string{document,
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3]}}`,
+		}, []string{document,
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3, "2020-01-01T00:01:00Z", 4, 5, 6]}}`,
+		}, 1},
+		{"new samples relative to an epoch", []string{document,
+			`{"id": "e", "position": {"epoch": "2020-01-01T00:00:00Z", "cartesian": [0, 1, 2, 3]}}`,
+		}, []string{document,
+			`{"id": "e", "position": {"epoch": "2020-01-01T00:00:00Z", "cartesian": [0, 1, 2, 3, 60, 4, 5, 6]}}`,
+		}, 1},
+		{"new samples of a double", []string{document,
+			`{"id": "e", "path": {"width": {"number": ["2020-01-01T00:00:00Z", 1]}}}`,
+		}, []string{document,
+			`{"id": "e", "path": {"width": {"number": ["2020-01-01T00:00:00Z", 1, "2020-01-01T00:01:00Z", 2]}}}`,
+		}, 1},
+		{"removed samples", []string{document,
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3, "2020-01-01T00:01:00Z", 4, 5, 6]}}`,
+		}, []string{document,
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3]}}`,
+		}, 2},
+		{"new interval", []string{document,
+			`{"id": "e", "billboard": {"show": [{"interval": "2020-01-01T00:00:00Z/2020-01-02T00:00:00Z", "boolean": true}]}}`,
+		}, []string{document,
+			`{"id": "e", "billboard": {"show": [{"interval": "2020-01-01T00:00:00Z/2020-01-02T00:00:00Z", "boolean": true},
+				{"interval": "2020-01-02T00:00:00Z/2020-01-03T00:00:00Z", "boolean": false}]}}`,
+		}, 1},
+		{"removed property", []string{document, `{"id": "e", "name": "a", "description": "b"}`},
+			[]string{document, `{"id": "e", "name": "a"}`}, 2},
+		{"changed representation", []string{document, `{"id": "e", "position": {"cartesian": [1, 2, 3]}}`},
+			[]string{document, `{"id": "e", "position": {"cartographicDegrees": [4, 5, 6]}}`}, 1},
+		{"changed document", []string{document}, []string{`{"id": "document", "version": "1.0", "name": "renamed"}`}, 1},
+		{"several entities", []string{document, `{"id": "a", "name": "a"}`, `{"id": "b", "name": "b"}`},
+			[]string{document, `{"id": "b", "name": "b2"}`, `{"id": "c", "name": "c"}`}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := Czml{Packets: parsePackets(t, tt.old)}
+			new := Czml{Packets: parsePackets(t, tt.new)}
+
+			packets := Diff(old, new)
+			if len(packets) != tt.packets {
+				t.Errorf("diff has %d packets, want %d: %s", len(packets), tt.packets, packetJSON(t, packets))
+			}
+
+			// applying the diff to old gives new
+			s := NewScene()
+			if err := s.Load(old); err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range packets {
+				if err := s.Apply(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want := NewScene()
+			if err := want.Load(new); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := packetJSON(t, s.Snapshot()), packetJSON(t, want.Snapshot()); got != want {
+				t.Errorf("applying the diff gives\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Encoder writes packets one at a time to a .czml stream, so that large documents can be
+// generated without buffering them in memory. The first packet written must be the only "document"
+// packet, and Close must be called to terminate the packet array.
+type Encoder struct {
+	w      io.Writer
+	prefix string
+	indent string
+	pretty bool
+	count  int
+	closed bool
+}
+
+// NewEncoder returns an Encoder that writes packets to w
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// SetIndent makes the Encoder format its output the same way MarshalIndent does. It must be called
+// before the first packet is written.
+func (e *Encoder) SetIndent(prefix, indent string) {
+	e.prefix = prefix
+	e.indent = indent
+	e.pretty = true
+}
+
+// WriteDocument writes a "document" packet with the provided name as the first packet
+func (e *Encoder) WriteDocument(name string) error {
+	var c Czml
+	c.InitializeDocument(name)
+	return e.Encode(c.Packets[0])
+}
+
+// Encode writes a Packet to the stream. The first Packet must be the "document" packet, and no
+// other Packet may have its id.
+func (e *Encoder) Encode(p Packet) error {
+	if e.closed {
+		return errors.New("encoder is closed")
+	}
+	if e.count == 0 && p.Id != "document" {
+		return errors.New("first packet must be the document packet")
+	}
+	if e.count > 0 && p.Id == "document" {
+		return errors.New("the document packet has already been written")
+	}
+
+	var data []byte
+	var err error
+	if e.pretty {
+		data, err = json.MarshalIndent(p, e.prefix+e.indent, e.indent)
+	} else {
+		data, err = json.Marshal(p)
+	}
+	if err != nil {
+		return err
+	}
+
+	sep := ","
+	if e.count == 0 {
+		sep = "["
+	}
+	if e.pretty {
+		sep += "\n" + e.prefix + e.indent
+	}
+
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+
+	e.count++
+	return nil
+}
+
+// Close terminates the packet array. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+
+	if e.count == 0 {
+		return errors.New("no document packet was written")
+	}
+
+	end := "]"
+	if e.pretty {
+		end = "\n" + e.prefix + end
+	}
+
+	_, err := io.WriteString(e.w, end)
+	return err
+}
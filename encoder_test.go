@@ -0,0 +1,105 @@
+package czml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncoderMatchesMarshal(t *testing.T) {
+	c := Czml{Packets: append([]Packet{{Id: "document", Name: "encoded", Version: "1.0"}}, parsePackets(t, []string{
+		`{"id": "a", "billboard": {"image": "pin.png", "scale": 2}}`,
+		`{"id": "b", "position": {"epoch": "2020-01-01T00:00:00Z", "cartesian": [0, 1, 2, 3, 60, 4, 5, 6]}}`,
+	})...)}
+
+	tests := []struct {
+		name           string
+		pretty         bool
+		prefix, indent string
+	}{
+		{"compact", false, "", ""},
+		{"tabs", true, "", "\t"},
+		{"spaces", true, "", "  "},
+		{"prefix", true, "// ", "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for n := 1; n <= len(c.Packets); n++ {
+				partial := Czml{Packets: c.Packets[:n]}
+				want, err := Marshal(partial)
+				if tt.pretty {
+					want, err = MarshalIndent(partial, tt.prefix, tt.indent)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var buf bytes.Buffer
+				e := NewEncoder(&buf)
+				if tt.pretty {
+					e.SetIndent(tt.prefix, tt.indent)
+				}
+				for _, p := range partial.Packets {
+					if err := e.Encode(p); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := e.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(buf.Bytes(), want) {
+					t.Errorf("%d packets written as\n%s\nwant\n%s", n, buf.Bytes(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	document := Packet{Id: "document", Version: "1.0"}
+
+	tests := []struct {
+		name  string
+		write func(e *Encoder) error
+	}{
+		{"first packet is not the document", func(e *Encoder) error {
+			return e.Encode(Packet{Id: "a"})
+		}},
+		{"second document packet", func(e *Encoder) error {
+			if err := e.Encode(document); err != nil {
+				t.Fatal(err)
+			}
+			return e.Encode(document)
+		}},
+		{"document packet after another packet", func(e *Encoder) error {
+			if err := e.WriteDocument("doc"); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.Encode(Packet{Id: "a"}); err != nil {
+				t.Fatal(err)
+			}
+			return e.Encode(document)
+		}},
+		{"packet after Close", func(e *Encoder) error {
+			if err := e.WriteDocument("doc"); err != nil {
+				t.Fatal(err)
+			}
+			if err := e.Close(); err != nil {
+				t.Fatal(err)
+			}
+			return e.Encode(Packet{Id: "a"})
+		}},
+		{"Close without a document", func(e *Encoder) error {
+			return e.Close()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.write(NewEncoder(&bytes.Buffer{})); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
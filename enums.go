@@ -0,0 +1,227 @@
+package czml
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Legal values of the string-valued properties, in the order the CZML spec lists them
+var (
+	shadowModes = []string{
+		string(ShadowModeDisabled),
+		string(ShadowModeEnabled),
+		string(ShadowModeCastOnly),
+		string(ShadowModeReceiveOnly),
+	}
+	classificationTypes = []string{
+		string(ClassificationTypeTerrain),
+		string(ClassificationTypeCesium3DTile),
+		string(ClassificationTypeBoth),
+	}
+	heightReferences = []string{
+		string(HeightReferenceNone),
+		string(HeightReferenceClampToGround),
+		string(HeightReferenceRelativeToGround),
+	}
+	horizontalOrigins = []string{
+		string(HorizontalOriginLeft),
+		string(HorizontalOriginCenter),
+		string(HorizontalOriginRight),
+	}
+	verticalOrigins = []string{
+		string(VerticalOriginBaseline),
+		string(VerticalOriginBottom),
+		string(VerticalOriginCenter),
+		string(VerticalOriginTop),
+	}
+	labelStyles = []string{
+		string(LabelStyleFill),
+		string(LabelStyleOutline),
+		string(LabelStyleFillAndOutline),
+	}
+	colorBlendModes = []string{
+		string(ColorBlendModeHighlight),
+		string(ColorBlendModeReplace),
+		string(ColorBlendModeMix),
+	}
+	arcTypes = []string{
+		string(ArcTypeNone),
+		string(ArcTypeGeodesic),
+		string(ArcTypeRhumb),
+	}
+	cornerTypes = []string{
+		string(CornerTypeRounded),
+		string(CornerTypeMitered),
+		string(CornerTypeBeveled),
+	}
+	stripeOrientations = []string{
+		string(StripeOrientationHorizontal),
+		string(StripeOrientationVertical),
+	}
+	portionsToDisplay = []string{
+		string(PortionToDisplayComplete),
+		string(PortionToDisplayBelowEllipsoidHorizon),
+		string(PortionToDisplayAboveEllipsoidHorizon),
+	}
+	interpolationAlgorithms = []string{
+		string(InterpolationLinear),
+		string(InterpolationLagrange),
+		string(InterpolationHermite),
+	}
+	extrapolationTypes = []string{
+		string(ExtrapolationNone),
+		string(ExtrapolationHold),
+		string(ExtrapolationExtrapolate),
+	}
+)
+
+// EnumError is returned when a string-valued property is encoded or decoded with a value that is
+// not one of its legal values
+type EnumError struct {
+	Type  string
+	Value string
+	Legal []string
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("czml: %q is not a valid %s, must be one of %s", e.Value, e.Type, strings.Join(e.Legal, ", "))
+}
+
+func isLegal(value string, legal []string) bool {
+	for _, l := range legal {
+		if value == l {
+			return true
+		}
+	}
+
+	return false
+}
+
+// marshalEnum writes a string-valued property. An empty value is treated as unset, as Validate
+// does, and written as an empty string.
+func marshalEnum(typ, value string, legal []string) ([]byte, error) {
+	if value != "" && !isLegal(value, legal) {
+		return nil, &EnumError{Type: typ, Value: value, Legal: legal}
+	}
+
+	return json.Marshal(value)
+}
+
+// unmarshalEnum reads a string-valued property, accepting the empty value marshalEnum writes
+func unmarshalEnum(data []byte, typ string, v *string, legal []string) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value != "" && !isLegal(value, legal) {
+		return &EnumError{Type: typ, Value: value, Legal: legal}
+	}
+
+	*v = value
+	return nil
+}
+
+func (m ShadowMode) MarshalJSON() ([]byte, error) {
+	return marshalEnum("ShadowMode", string(m), shadowModes)
+}
+
+func (m *ShadowMode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "ShadowMode", (*string)(m), shadowModes)
+}
+
+func (t ClassificationType) MarshalJSON() ([]byte, error) {
+	return marshalEnum("ClassificationType", string(t), classificationTypes)
+}
+
+func (t *ClassificationType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "ClassificationType", (*string)(t), classificationTypes)
+}
+
+func (v HeightReferenceValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("HeightReferenceValue", string(v), heightReferences)
+}
+
+func (v *HeightReferenceValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "HeightReferenceValue", (*string)(v), heightReferences)
+}
+
+func (v HorizontalOriginValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("HorizontalOriginValue", string(v), horizontalOrigins)
+}
+
+func (v *HorizontalOriginValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "HorizontalOriginValue", (*string)(v), horizontalOrigins)
+}
+
+func (v VerticalOriginValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("VerticalOriginValue", string(v), verticalOrigins)
+}
+
+func (v *VerticalOriginValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "VerticalOriginValue", (*string)(v), verticalOrigins)
+}
+
+func (v LabelStyleValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("LabelStyleValue", string(v), labelStyles)
+}
+
+func (v *LabelStyleValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "LabelStyleValue", (*string)(v), labelStyles)
+}
+
+func (v ColorBlendModeValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("ColorBlendModeValue", string(v), colorBlendModes)
+}
+
+func (v *ColorBlendModeValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "ColorBlendModeValue", (*string)(v), colorBlendModes)
+}
+
+func (v ArcTypeValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("ArcTypeValue", string(v), arcTypes)
+}
+
+func (v *ArcTypeValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "ArcTypeValue", (*string)(v), arcTypes)
+}
+
+func (v CornerTypeValue) MarshalJSON() ([]byte, error) {
+	return marshalEnum("CornerTypeValue", string(v), cornerTypes)
+}
+
+func (v *CornerTypeValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "CornerTypeValue", (*string)(v), cornerTypes)
+}
+
+func (o StripeOrientation) MarshalJSON() ([]byte, error) {
+	return marshalEnum("StripeOrientation", string(o), stripeOrientations)
+}
+
+func (o *StripeOrientation) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "StripeOrientation", (*string)(o), stripeOrientations)
+}
+
+func (p SensorVolumePortionToDisplay) MarshalJSON() ([]byte, error) {
+	return marshalEnum("SensorVolumePortionToDisplay", string(p), portionsToDisplay)
+}
+
+func (p *SensorVolumePortionToDisplay) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "SensorVolumePortionToDisplay", (*string)(p), portionsToDisplay)
+}
+
+func (a InterpolationAlgorithm) MarshalJSON() ([]byte, error) {
+	return marshalEnum("InterpolationAlgorithm", string(a), interpolationAlgorithms)
+}
+
+func (a *InterpolationAlgorithm) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "InterpolationAlgorithm", (*string)(a), interpolationAlgorithms)
+}
+
+func (t ExtrapolationType) MarshalJSON() ([]byte, error) {
+	return marshalEnum("ExtrapolationType", string(t), extrapolationTypes)
+}
+
+func (t *ExtrapolationType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "ExtrapolationType", (*string)(t), extrapolationTypes)
+}
@@ -0,0 +1,97 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// enumTest marshals and unmarshals values of one of the string enumerations
+type enumTest struct {
+	legal     string
+	marshal   func(v string) ([]byte, error)
+	unmarshal func(data []byte) (string, error)
+}
+
+func newEnumTest[T ~string](legal T) enumTest {
+	return enumTest{
+		legal:   string(legal),
+		marshal: func(v string) ([]byte, error) { return json.Marshal(T(v)) },
+		unmarshal: func(data []byte) (string, error) {
+			var v T
+			err := json.Unmarshal(data, &v)
+			return string(v), err
+		},
+	}
+}
+
+func TestEnums(t *testing.T) {
+	tests := []struct {
+		name string
+		enum enumTest
+	}{
+		{"ShadowMode", newEnumTest(ShadowModeCastOnly)},
+		{"ClassificationType", newEnumTest(ClassificationTypeCesium3DTile)},
+		{"HeightReference", newEnumTest(HeightReferenceClampToGround)},
+		{"HorizontalOrigin", newEnumTest(HorizontalOriginLeft)},
+		{"VerticalOrigin", newEnumTest(VerticalOriginBaseline)},
+		{"LabelStyle", newEnumTest(LabelStyleFillAndOutline)},
+		{"ColorBlendMode", newEnumTest(ColorBlendModeMix)},
+		{"ArcType", newEnumTest(ArcTypeRhumb)},
+		{"CornerType", newEnumTest(CornerTypeBeveled)},
+		{"StripeOrientation", newEnumTest(StripeOrientationVertical)},
+		{"PortionToDisplay", newEnumTest(PortionToDisplayAboveEllipsoidHorizon)},
+		{"InterpolationAlgorithm", newEnumTest(InterpolationHermite)},
+		{"ExtrapolationType", newEnumTest(ExtrapolationExtrapolate)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// legal and empty values are written and read back
+			for _, value := range []string{tt.enum.legal, ""} {
+				data, err := tt.enum.marshal(value)
+				if err != nil {
+					t.Fatalf("writing %q: %v", value, err)
+				}
+				got, err := tt.enum.unmarshal(data)
+				if err != nil || got != value {
+					t.Errorf("read %s as %q, %v", data, got, err)
+				}
+			}
+
+			// unknown values, including legal values in the wrong case, are rejected both ways
+			for _, value := range []string{"UNKNOWN", strings.ToLower(tt.enum.legal)} {
+				var enumErr *EnumError
+				if _, err := tt.enum.marshal(value); !errors.As(err, &enumErr) || enumErr.Value != value {
+					t.Errorf("writing %q: error is %v, want an EnumError", value, err)
+				}
+				if _, err := tt.enum.unmarshal([]byte(`"` + value + `"`)); !errors.As(err, &enumErr) || enumErr.Value != value {
+					t.Errorf("reading %q: error is %v, want an EnumError", value, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnknownEnums(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet string
+	}{
+		{"horizontal origin", `{"id": "a", "billboard": {"horizontalOrigin": {"horizontalOrigin": "MIDDLE"}}}`},
+		{"shadow mode interval", `{"id": "a", "model": {"shadows": [{"interval": "2020-01-01T00:00:00Z/2020-01-02T00:00:00Z", "shadowMode": "ALWAYS"}]}}`},
+		{"interpolation algorithm", `{"id": "a", "position": {"interpolationAlgorithm": "CUBIC", "cartesian": [0, 1, 2, 3]}}`},
+		{"stripe orientation", `{"id": "a", "polygon": {"material": {"stripe": {"orientation": "DIAGONAL"}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Packet
+			var enumErr *EnumError
+			if err := json.Unmarshal([]byte(tt.packet), &p); !errors.As(err, &enumErr) {
+				t.Errorf("error is %v, want an EnumError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package czml
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrNoValue is returned when a property has no value at the requested time
+var ErrNoValue = errors.New("czml: property has no value at the requested time")
+
+// Evaluate returns the value of v at the provided time. Constant values are returned unchanged.
+// Samples are interpolated and extrapolated the same way Cesium's SampledProperty does, using the
+// algorithm, degree, extrapolation options and epoch in i. ErrNoValue is returned when the time is
+// outside the samples and extrapolation does not apply.
+func (i Interpolatable) Evaluate(v SampledValue, at time.Time) ([]float64, error) {
+	return i.evaluate(v, at, 0)
+}
+
+// ValueAt returns the position at the provided time, in the representation the Position is given
+// in. Cartographic positions are interpolated in Earth-fixed Cartesian coordinates, as Cesium does,
+// and converted back. Cartesian velocity positions are interpolated with the Hermite algorithm
+// using their derivatives, and return both the position and the velocity.
+func (p *Position) ValueAt(at time.Time) ([]float64, error) {
+	switch {
+	case p.CartesianVelocity != nil:
+		i := p.Interpolatable
+		if i.InterpolationAlgorithm == InterpolationHermite {
+			return i.evaluate(p.CartesianVelocity.SampledValue, at, 1)
+		}
+		return i.evaluate(p.CartesianVelocity.SampledValue, at, 0)
+	case p.Cartesian != nil:
+		return p.Evaluate(p.Cartesian.SampledValue, at)
+	case p.CartographicRadians != nil:
+		return p.evaluateCartographic(p.CartographicRadians.SampledValue, at, 1)
+	case p.CartographicDegrees != nil:
+		return p.evaluateCartographic(p.CartographicDegrees.SampledValue, at, math.Pi/180)
+	case p.Reference != "":
+		return nil, errors.New("czml: position is a reference, which must be resolved before it is evaluated")
+	}
+
+	return nil, ErrNoValue
+}
+
+// ValueAt returns the number at the provided time, interpolating samples with the options of the
+// double
+func (d *Double) ValueAt(at time.Time) (float64, error) {
+	switch {
+	case d.Number != nil:
+		v, err := d.Evaluate(d.Number.SampledValue, at)
+		if err != nil {
+			return 0, err
+		}
+		if len(v) == 0 {
+			return 0, ErrNoValue
+		}
+		return v[0], nil
+	case d.Reference != "":
+		return 0, errors.New("czml: double is a reference, which must be resolved before it is evaluated")
+	}
+
+	return 0, ErrNoValue
+}
+
+// evaluateCartographic interpolates cartographic samples in Cartesian coordinates. scale converts
+// the angles of the samples to radians.
+func (p *Position) evaluateCartographic(v SampledValue, at time.Time, scale float64) ([]float64, error) {
+	if !v.IsSampled() {
+		return v.Value, nil
+	}
+
+	cartesian := SampledValue{Samples: make([]Sample, len(v.Samples))}
+	for i, s := range v.Samples {
+		c := CartographicToCartesian(s.Value[0]*scale, s.Value[1]*scale, s.Value[2])
+		cartesian.Samples[i] = Sample{Time: s.Time, Value: c[:]}
+	}
+
+	value, err := p.Evaluate(cartesian, at)
+	if err != nil {
+		return nil, err
+	}
+
+	lon, lat, height := CartesianToCartographic([3]float64{value[0], value[1], value[2]})
+	return []float64{lon / scale, lat / scale, height}, nil
+}
+
+// evaluate interpolates samples whose values are followed by inputOrder derivatives of the same
+// dimension
+func (i Interpolatable) evaluate(v SampledValue, at time.Time, inputOrder int) ([]float64, error) {
+	if !v.IsSampled() {
+		if v.Value == nil {
+			return nil, ErrNoValue
+		}
+		return v.Value, nil
+	}
+
+	samples, times, err := i.sortedSamples(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// times are in seconds since the first sample
+	x := secondsBetween(times[0], at)
+	xs := make([]float64, len(times))
+	for j, t := range times {
+		xs[j] = secondsBetween(times[0], t)
+	}
+
+	index := sort.SearchFloat64s(xs, x)
+	if index < len(xs) && xs[index] == x {
+		return samples[index].Value, nil
+	}
+
+	if index == 0 {
+		timeout := durationOrZero(i.BackwardExtrapolationDuration)
+		switch {
+		case i.backwardExtrapolation() == ExtrapolationNone, timeout != 0 && xs[0]-x > timeout:
+			return nil, ErrNoValue
+		case i.backwardExtrapolation() == ExtrapolationHold:
+			return samples[0].Value, nil
+		}
+	}
+	if index >= len(xs) {
+		index = len(xs) - 1
+		timeout := durationOrZero(i.ForwardExtrapolationDuration)
+		switch {
+		case i.forwardExtrapolation() == ExtrapolationNone, timeout != 0 && x-xs[index] > timeout:
+			return nil, ErrNoValue
+		case i.forwardExtrapolation() == ExtrapolationHold:
+			return samples[index].Value, nil
+		}
+	}
+
+	points := i.requiredPoints(inputOrder)
+	if points > len(xs) {
+		points = len(xs)
+	}
+	length := points - 1
+	if length < 1 {
+		return nil, ErrNoValue
+	}
+
+	// choose a window of samples centered on the requested time, as Cesium does
+	first, last := 0, len(xs)-1
+	if last-first+1 >= points {
+		first = index - length/2 - 1
+		if first < 0 {
+			first = 0
+		}
+		last = first + length
+		if last > len(xs)-1 {
+			last = len(xs) - 1
+			first = last - length
+			if first < 0 {
+				first = 0
+			}
+		}
+	}
+
+	window := samples[first : last+1]
+	wx := make([]float64, len(window))
+	for j := range window {
+		wx[j] = xs[first+j] - xs[last]
+	}
+	x -= xs[last]
+
+	dimension := len(window[0].Value) / (inputOrder + 1)
+	switch {
+	case i.algorithm() == InterpolationLinear:
+		return linear(x, wx, window), nil
+	case i.algorithm() == InterpolationHermite && inputOrder > 0:
+		return hermite(x, wx, window, dimension), nil
+	default:
+		return lagrange(x, wx, window, dimension), nil
+	}
+}
+
+// sortedSamples returns the samples of v ordered by time, along with their absolute times
+func (i Interpolatable) sortedSamples(v SampledValue) ([]Sample, []time.Time, error) {
+	samples := make([]Sample, len(v.Samples))
+	copy(samples, v.Samples)
+
+	times := make([]time.Time, len(samples))
+	for j, s := range samples {
+		t, err := s.Time.Resolve(i.Epoch)
+		if err != nil {
+			return nil, nil, err
+		}
+		times[j] = t
+	}
+
+	if !sort.SliceIsSorted(times, func(a, b int) bool { return times[a].Before(times[b]) }) {
+		order := make([]int, len(samples))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool { return times[order[a]].Before(times[order[b]]) })
+
+		sortedSamples := make([]Sample, len(samples))
+		sortedTimes := make([]time.Time, len(samples))
+		for j, o := range order {
+			sortedSamples[j] = samples[o]
+			sortedTimes[j] = times[o]
+		}
+		samples, times = sortedSamples, sortedTimes
+	}
+
+	return samples, times, nil
+}
+
+func (i Interpolatable) algorithm() InterpolationAlgorithm {
+	if i.InterpolationAlgorithm == "" {
+		return InterpolationLinear
+	}
+
+	return i.InterpolationAlgorithm
+}
+
+func (i Interpolatable) degree() int {
+	if i.InterpolationDegree == nil {
+		return 1
+	}
+
+	return *i.InterpolationDegree
+}
+
+func (i Interpolatable) forwardExtrapolation() ExtrapolationType {
+	if i.ForwardExtrapolationType == "" {
+		return ExtrapolationNone
+	}
+
+	return i.ForwardExtrapolationType
+}
+
+func (i Interpolatable) backwardExtrapolation() ExtrapolationType {
+	if i.BackwardExtrapolationType == "" {
+		return ExtrapolationNone
+	}
+
+	return i.BackwardExtrapolationType
+}
+
+// requiredPoints returns the number of samples the algorithm needs for the configured degree
+func (i Interpolatable) requiredPoints(inputOrder int) int {
+	switch i.algorithm() {
+	case InterpolationLinear:
+		return 2
+	case InterpolationHermite:
+		points := (i.degree() + 1) / (inputOrder + 1)
+		if points < 2 {
+			return 2
+		}
+		return points
+	default:
+		return i.degree() + 1
+	}
+}
+
+func durationOrZero(d *float64) float64 {
+	if d == nil {
+		return 0
+	}
+
+	return *d
+}
+
+// linear interpolates between the last two samples of the window
+func linear(x float64, xs []float64, window []Sample) []float64 {
+	x0, x1 := xs[len(xs)-2], xs[len(xs)-1]
+	y0, y1 := window[len(window)-2].Value, window[len(window)-1].Value
+
+	t := (x - x0) / (x1 - x0)
+	result := make([]float64, len(y0))
+	for j := range result {
+		result[j] = (1-t)*y0[j] + t*y1[j]
+	}
+
+	return result
+}
+
+// lagrange evaluates the Lagrange polynomial through the samples of the window
+func lagrange(x float64, xs []float64, window []Sample, dimension int) []float64 {
+	result := make([]float64, dimension)
+	for j := range window {
+		coefficient := 1.0
+		for k := range window {
+			if k != j {
+				coefficient *= (x - xs[k]) / (xs[j] - xs[k])
+			}
+		}
+		for d := 0; d < dimension; d++ {
+			result[d] += coefficient * window[j].Value[d]
+		}
+	}
+
+	return result
+}
+
+// hermite evaluates the Hermite polynomial through the samples of the window, whose values are
+// followed by their first derivatives. It returns the interpolated value followed by its derivative.
+func hermite(x float64, xs []float64, window []Sample, dimension int) []float64 {
+	n := 2 * len(window)
+	z := make([]float64, n)
+	for j := range window {
+		z[2*j], z[2*j+1] = xs[j], xs[j]
+	}
+
+	result := make([]float64, 2*dimension)
+	q := make([]float64, n)
+	for d := 0; d < dimension; d++ {
+		// divided differences with every node repeated, using the derivative where nodes coincide
+		for j := range window {
+			q[2*j] = window[j].Value[d]
+			q[2*j+1] = window[j].Value[d]
+		}
+		coefficients := make([]float64, n)
+		coefficients[0] = q[0]
+		for level := 1; level < n; level++ {
+			for j := n - 1; j >= level; j-- {
+				if z[j] == z[j-level] {
+					q[j] = window[j/2].Value[dimension+d]
+				} else {
+					q[j] = (q[j] - q[j-1]) / (z[j] - z[j-level])
+				}
+			}
+			coefficients[level] = q[level]
+		}
+
+		// Horner's method on the Newton form, carrying the derivative along
+		value, derivative := coefficients[n-1], 0.0
+		for j := n - 2; j >= 0; j-- {
+			derivative = derivative*(x-z[j]) + value
+			value = value*(x-z[j]) + coefficients[j]
+		}
+
+		result[d] = value
+		result[dimension+d] = derivative
+	}
+
+	return result
+}
@@ -0,0 +1,137 @@
+package czml
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// sampled returns samples of f at the provided seconds since epoch
+func sampled(f func(float64) float64, seconds ...float64) SampledValue {
+	var v SampledValue
+	for _, s := range seconds {
+		v.AddSample(SecondsTag(s), f(s))
+	}
+
+	return v
+}
+
+func TestEvaluate(t *testing.T) {
+	epoch := NewJulianDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	at := func(seconds float64) time.Time {
+		return epoch.Add(time.Duration(seconds * float64(time.Second)))
+	}
+	degree := func(d int) *int { return &d }
+	duration := func(d float64) *float64 { return &d }
+
+	square := func(x float64) float64 { return x * x }
+	cubic := func(x float64) float64 { return x*x*x - 2*x + 1 }
+	piecewise := func(x float64) float64 { return math.Max(x, 2*x-10) }
+
+	tests := []struct {
+		name    string
+		options Interpolatable
+		value   SampledValue
+		at      float64
+		want    float64
+		wantErr error
+	}{
+		{"constant", Interpolatable{}, SampledValue{Value: []float64{7}}, 100, 7, nil},
+		{"at a sample", Interpolatable{}, sampled(square, 0, 1, 2), 1, 1, nil},
+		{"linear by default", Interpolatable{}, sampled(piecewise, 0, 10, 20), 15, 20, nil},
+		{"unsorted samples", Interpolatable{}, sampled(piecewise, 20, 0, 10), 15, 20, nil},
+		{"lagrange is exact on polynomials of its degree", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(2)},
+			sampled(square, 0, 1, 2, 3, 4, 5), 2.5, 6.25, nil},
+		{"lagrange of degree 3", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(3)},
+			sampled(cubic, 0, 1, 2, 3, 4, 5, 6), 3.7, cubic(3.7), nil},
+		{"lagrange near the first sample", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(3)},
+			sampled(cubic, 0, 1, 2, 3, 4, 5, 6), 0.2, cubic(0.2), nil},
+		{"lagrange near the last sample", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(3)},
+			sampled(cubic, 0, 1, 2, 3, 4, 5, 6), 5.9, cubic(5.9), nil},
+		// the window of 3 samples around 2.5 is 1, 2 and 3, as in Cesium's SampledProperty
+		{"lagrange window", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(2)},
+			sampled(cubic, 0, 1, 2, 3, 4, 5, 6), 2.5, 12, nil},
+		{"lagrange with too few samples", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(5)},
+			sampled(square, 0, 1, 2), 1.5, 2.25, nil},
+		{"hermite without derivatives", Interpolatable{InterpolationAlgorithm: InterpolationHermite, InterpolationDegree: degree(3)},
+			sampled(cubic, 0, 1, 2, 3, 4, 5, 6), 3.7, cubic(3.7), nil},
+		{"no extrapolation by default", Interpolatable{}, sampled(square, 0, 10), 15, 0, ErrNoValue},
+		{"no backward extrapolation by default", Interpolatable{}, sampled(square, 0, 10), -1, 0, ErrNoValue},
+		{"hold forward", Interpolatable{ForwardExtrapolationType: ExtrapolationHold}, sampled(square, 0, 10), 15, 100, nil},
+		{"hold backward", Interpolatable{BackwardExtrapolationType: ExtrapolationHold}, sampled(square, 1, 10), -5, 1, nil},
+		{"extrapolate forward", Interpolatable{ForwardExtrapolationType: ExtrapolationExtrapolate}, sampled(piecewise, 0, 10), 15, 15, nil},
+		{"extrapolate backward", Interpolatable{BackwardExtrapolationType: ExtrapolationExtrapolate}, sampled(piecewise, 0, 10), -5, -5, nil},
+		{"extrapolate within the duration", Interpolatable{ForwardExtrapolationType: ExtrapolationExtrapolate, ForwardExtrapolationDuration: duration(5)},
+			sampled(piecewise, 0, 10), 15, 15, nil},
+		{"extrapolate beyond the duration", Interpolatable{ForwardExtrapolationType: ExtrapolationExtrapolate, ForwardExtrapolationDuration: duration(5)},
+			sampled(piecewise, 0, 10), 15.5, 0, ErrNoValue},
+		{"hold beyond the duration", Interpolatable{BackwardExtrapolationType: ExtrapolationHold, BackwardExtrapolationDuration: duration(1)},
+			sampled(square, 0, 10), -2, 0, ErrNoValue},
+		{"lagrange extrapolation", Interpolatable{InterpolationAlgorithm: InterpolationLagrange, InterpolationDegree: degree(2), ForwardExtrapolationType: ExtrapolationExtrapolate},
+			sampled(square, 0, 1, 2, 3), 4, 16, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Epoch = &epoch
+			got, err := tt.options.Evaluate(tt.value, at(tt.at))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error is %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || math.Abs(got[0]-tt.want) > 1e-9 {
+				t.Errorf("value is %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHermiteWithVelocities(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	position := func(s float64) []float64 { return []float64{s * s * s, 2 * s, 5} }
+	velocity := func(s float64) []float64 { return []float64{3 * s * s, 2, 0} }
+
+	tests := []struct {
+		name   string
+		degree int
+		times  []float64
+		at     float64
+	}{
+		// two samples with velocities are enough for a cubic
+		{"cubic between two samples", 3, []float64{0, 1}, 0.5},
+		{"cubic between later samples", 3, []float64{0, 1, 2, 3}, 2.25},
+		{"quintic window", 5, []float64{0, 1, 2, 3}, 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Position{CartesianVelocity: &Cartesian3VelocityValue{}}
+			p.SetInterpolation(InterpolationHermite, tt.degree)
+			for _, s := range tt.times {
+				p.CartesianVelocity.AddSample(DateTag(start.Add(time.Duration(s*float64(time.Second)))),
+					append(position(s), velocity(s)...)...)
+			}
+
+			got, err := p.ValueAt(start.Add(time.Duration(tt.at * float64(time.Second))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := append(position(tt.at), velocity(tt.at)...)
+			if len(got) != len(want) {
+				t.Fatalf("value is %v, want %v", got, want)
+			}
+			for i := range want {
+				if math.Abs(got[i]-want[i]) > 1e-9 {
+					t.Errorf("value is %v, want %v", got, want)
+					break
+				}
+			}
+		})
+	}
+}
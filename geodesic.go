@@ -0,0 +1,346 @@
+package czml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Spacing is the largest distance allowed between neighbouring positions, either in meters along
+// the surface of the Earth or, like Granularity, as an angle in radians. Angles are turned into
+// distances along the equator, as Cesium does.
+type Spacing struct {
+	Meters  float64
+	Radians float64
+}
+
+// meters returns the spacing as a distance
+func (s Spacing) meters() (float64, error) {
+	switch {
+	case s.Meters > 0:
+		return s.Meters, nil
+	case s.Radians > 0:
+		return s.Radians * wgs84A, nil
+	}
+
+	return 0, errors.New("czml: spacing must be greater than zero")
+}
+
+// Densify returns a copy of the list with positions inserted along each segment, so that no two
+// neighbouring positions are further apart than spacing. Segments follow the WGS84 geodesic for
+// GEODESIC or an unset arc type, and the line of constant bearing for RHUMB. Heights change
+// linearly along each segment. Lists with arc type NONE are drawn with straight lines, and are
+// returned unchanged. The copy uses the representation of the list.
+func (p *PositionList) Densify(arcType ArcTypeValue, spacing Spacing) (*PositionList, error) {
+	maximum, err := spacing.meters()
+	if err != nil {
+		return nil, err
+	}
+	representation, err := p.representation()
+	if err != nil {
+		return nil, err
+	}
+
+	switch arcType {
+	case ArcTypeNone:
+		return p.As(representation)
+	case "", ArcTypeGeodesic, ArcTypeRhumb:
+	default:
+		return nil, fmt.Errorf("czml: unknown arc type %q", arcType)
+	}
+
+	radians, err := p.As(RepresentationCartographicRadians)
+	if err != nil {
+		return nil, err
+	}
+
+	list := *radians.CartographicRadians
+	var densified CartographicRadiansListValue
+	for i := 0; i+2 < len(list); i += 3 {
+		if i == 0 {
+			densified = append(densified, list[0], list[1], list[2])
+			continue
+		}
+
+		start := [3]float64{list[i-3], list[i-2], list[i-1]}
+		end := [3]float64{list[i], list[i+1], list[i+2]}
+		s, err := newSegment(arcType, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		n := math.Max(1, math.Ceil(s.length/maximum))
+		for j := 1.0; j < n; j++ {
+			lon, lat := s.at(s.length * j / n)
+			densified = append(densified, lon, lat, start[2]+(end[2]-start[2])*j/n)
+		}
+		densified = append(densified, end[0], end[1], end[2])
+	}
+
+	return (&PositionList{ReferenceFrame: p.ReferenceFrame, CartographicRadians: &densified}).As(representation)
+}
+
+// Length returns the length of the list in meters. Lengths along GEODESIC and RHUMB segments are
+// measured on the WGS84 ellipsoid, ignoring heights, and NONE segments are straight lines between
+// the Earth-fixed positions.
+func (p *PositionList) Length(arcType ArcTypeValue) (float64, error) {
+	switch arcType {
+	case ArcTypeNone:
+		fixed, err := p.As(RepresentationCartesian)
+		if err != nil {
+			return 0, err
+		}
+
+		length, list := 0.0, *fixed.Cartesian
+		for i := 3; i+2 < len(list); i += 3 {
+			start := cartesian{list[i-3], list[i-2], list[i-1]}
+			length += cartesian{list[i], list[i+1], list[i+2]}.sub(start).length()
+		}
+		return length, nil
+	case "", ArcTypeGeodesic, ArcTypeRhumb:
+		radians, err := p.As(RepresentationCartographicRadians)
+		if err != nil {
+			return 0, err
+		}
+
+		length, list := 0.0, *radians.CartographicRadians
+		for i := 3; i+2 < len(list); i += 3 {
+			s, err := newSegment(arcType, [3]float64{list[i-3], list[i-2]}, [3]float64{list[i], list[i+1]})
+			if err != nil {
+				return 0, err
+			}
+			length += s.length
+		}
+		return length, nil
+	}
+
+	return 0, fmt.Errorf("czml: unknown arc type %q", arcType)
+}
+
+// representation returns the representation the list is given in
+func (p *PositionList) representation() (Representation, error) {
+	switch {
+	case p.Cartesian != nil:
+		return RepresentationCartesian, nil
+	case p.CartographicRadians != nil:
+		return RepresentationCartographicRadians, nil
+	case p.CartographicDegrees != nil:
+		return RepresentationCartographicDegrees, nil
+	case p.References != nil:
+		return "", errors.New("czml: references cannot be converted")
+	}
+
+	return "", errors.New("czml: position list has no value to convert")
+}
+
+// segment is a curve on the WGS84 ellipsoid between two positions
+type segment struct {
+	length float64
+	at     func(distance float64) (lon, lat float64)
+}
+
+// newSegment returns the geodesic or rhumb line from start to end, given in radians
+func newSegment(arcType ArcTypeValue, start, end [3]float64) (segment, error) {
+	if arcType == ArcTypeRhumb {
+		return rhumbLine(start[0], start[1], end[0], end[1]), nil
+	}
+
+	length, azimuth, err := vincentyInverse(start[0], start[1], end[0], end[1])
+	if err != nil {
+		return segment{}, err
+	}
+
+	return segment{length: length, at: func(distance float64) (float64, float64) {
+		return vincentyDirect(start[0], start[1], azimuth, distance)
+	}}, nil
+}
+
+// vincentyInverse returns the length of the geodesic between two positions and its azimuth at the
+// first, using Vincenty's inverse formula. Nearly antipodal positions, for which the formula does
+// not converge, are solved with Karney's method instead.
+func vincentyInverse(lon1, lat1, lon2, lat2 float64) (float64, float64, error) {
+	l := lon2 - lon1
+	u1 := math.Atan((1 - wgs84F) * math.Tan(lat1))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(lat2))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM, sinLambda, cosLambda float64
+	for i := 0; ; i++ {
+		if i == 200 {
+			return karneyInverse(lon1, lat1, lon2, lat2)
+		}
+
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		if sinSigma == 0 {
+			if cosSigma < 0 {
+				// the positions are antipodal
+				return karneyInverse(lon1, lat1, lon2, lat2)
+			}
+			// the positions are the same
+			return 0, 0, nil
+		}
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			// off the equator
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < 1e-12 {
+			break
+		}
+	}
+
+	a, b := vincentyCoefficients(cos2Alpha)
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	length := wgs84B * a * (sigma - deltaSigma)
+	azimuth := math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+
+	return length, azimuth, nil
+}
+
+// vincentyDirect returns the position at a distance along the geodesic leaving a position at an
+// azimuth, using Vincenty's direct formula
+func vincentyDirect(lon, lat, azimuth, distance float64) (float64, float64) {
+	sinAlpha1, cosAlpha1 := math.Sincos(azimuth)
+	u1 := math.Atan((1 - wgs84F) * math.Tan(lat))
+	sinU1, cosU1 := math.Sincos(u1)
+	sigma1 := math.Atan2(math.Tan(u1), cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1 - sinAlpha*sinAlpha
+	a, b := vincentyCoefficients(cos2Alpha)
+
+	sigma := distance / (wgs84B * a)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < 200; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		previous := sigma
+		sigma = distance/(wgs84B*a) + deltaSigma
+		if math.Abs(sigma-previous) < 1e-12 {
+			break
+		}
+	}
+	sinSigma, cosSigma = math.Sincos(sigma)
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+
+	tmp := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-wgs84F)*math.Hypot(sinAlpha, tmp))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+	l := lambda - (1-c)*wgs84F*sinAlpha*
+		(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	return normalizeLongitude(lon + l), lat2
+}
+
+// vincentyCoefficients returns Vincenty's A and B for the square of the cosine of the azimuth of a
+// geodesic at the equator
+func vincentyCoefficients(cos2Alpha float64) (float64, float64) {
+	u2 := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	a := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	b := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+
+	return a, b
+}
+
+// rhumbLine returns the line of constant bearing between two positions on the ellipsoid, taking the
+// shorter way around
+func rhumbLine(lon1, lat1, lon2, lat2 float64) segment {
+	dLon := normalizeLongitude(lon2 - lon1)
+	psi1 := isometricLatitude(lat1)
+	dPsi := isometricLatitude(lat2) - psi1
+	m1 := meridianDistance(lat1)
+	dM := meridianDistance(lat2) - m1
+
+	// the east-west distance is the change of longitude times the ratio of the meridian distance
+	// to the change of isometric latitude. Dividing the meridian distance by the cosine of the
+	// bearing instead is not stable near east and west, and for small changes of latitude the
+	// ratio is the radius of the parallel between the positions, as the differences lose their
+	// precision.
+	nearlyParallel := math.Abs(lat2-lat1) < 1e-6
+	var q float64
+	if nearlyParallel {
+		lat := (lat1 + lat2) / 2
+		q = wgs84A * math.Cos(lat) / math.Sqrt(1-wgs84E2*math.Sin(lat)*math.Sin(lat))
+	} else {
+		q = dM / dPsi
+	}
+	length := math.Hypot(dM, dLon*q)
+
+	return segment{length: length, at: func(distance float64) (float64, float64) {
+		if length == 0 {
+			return lon1, lat1
+		}
+		f := distance / length
+		if nearlyParallel {
+			return normalizeLongitude(lon1 + f*dLon), lat1 + f*(lat2-lat1)
+		}
+
+		lat := latitudeOfMeridianDistance(m1 + f*dM)
+		return normalizeLongitude(lon1 + dLon*(isometricLatitude(lat)-psi1)/dPsi), lat
+	}}
+}
+
+// isometricLatitude returns the isometric latitude on the ellipsoid of a geodetic latitude
+func isometricLatitude(lat float64) float64 {
+	e := math.Sqrt(wgs84E2)
+	sinLat := math.Sin(lat)
+
+	return math.Atanh(sinLat) - e*math.Atanh(e*sinLat)
+}
+
+// meridianDistance returns the distance along a meridian from the equator to a latitude
+func meridianDistance(lat float64) float64 {
+	e2, e4, e6 := wgs84E2, wgs84E2*wgs84E2, wgs84E2*wgs84E2*wgs84E2
+
+	return wgs84A * ((1-e2/4-3*e4/64-5*e6/256)*lat -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*lat) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*lat) -
+		(35*e6/3072)*math.Sin(6*lat))
+}
+
+// latitudeOfMeridianDistance is the inverse of meridianDistance
+func latitudeOfMeridianDistance(m float64) float64 {
+	e2, e4, e6 := wgs84E2, wgs84E2*wgs84E2, wgs84E2*wgs84E2*wgs84E2
+	mu := m / (wgs84A * (1 - e2/4 - 3*e4/64 - 5*e6/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+
+	lat := mu + (3*e1/2-27*e1*e1*e1/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*e1*e1*e1*e1/32)*math.Sin(4*mu) +
+		(151*e1*e1*e1/96)*math.Sin(6*mu) +
+		(1097*e1*e1*e1*e1/512)*math.Sin(8*mu)
+
+	// Newton's method makes the series an exact inverse, as rhumb lines close to east or west
+	// divide small changes of latitude
+	for i := 0; i < 2; i++ {
+		sinLat := math.Sin(lat)
+		rho := wgs84A * (1 - e2) / math.Pow(1-e2*sinLat*sinLat, 1.5)
+		lat -= (meridianDistance(lat) - m) / rho
+	}
+
+	return lat
+}
+
+// normalizeLongitude returns a longitude in radians between -π and π
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon+math.Pi, 2*math.Pi)
+	if lon < 0 {
+		lon += 2 * math.Pi
+	}
+
+	return lon - math.Pi
+}
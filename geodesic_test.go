@@ -0,0 +1,132 @@
+package czml
+
+import (
+	"math"
+	"testing"
+)
+
+// dms returns an angle in degrees from degrees, minutes and seconds
+func dms(d, m, s float64) float64 {
+	return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+func TestLength(t *testing.T) {
+	flindersPeak := []float64{dms(144, 25, 29.52440), dms(-37, 57, 3.72030), 0}
+	buninyong := []float64{dms(143, 55, 35.38390), dms(-37, 39, 10.15610), 0}
+
+	tests := []struct {
+		name      string
+		arcType   ArcTypeValue
+		positions []float64
+		want      float64
+		tolerance float64
+	}{
+		// Vincenty's example from Survey Review XXIII, 176
+		{"geodesic Flinders Peak to Buninyong", ArcTypeGeodesic, append(flindersPeak, buninyong...), 54972.271, 0.001},
+		{"geodesic along the equator", ArcTypeGeodesic, []float64{0, 0, 0, 1, 0, 0}, 111319.491, 0.001},
+		{"geodesic along a meridian", ArcTypeGeodesic, []float64{0, 0, 0, 0, 1, 0}, 110574.389, 0.001},
+		// Karney's values for nearly antipodal positions, where Vincenty's formula fails
+		{"geodesic nearly antipodal", ArcTypeGeodesic, []float64{0, 0, 0, 179.5, 0, 0}, 19980861.909, 0.001},
+		{"geodesic nearly antipodal off the equator", ArcTypeGeodesic, []float64{0, 0, 0, 179.5, 0.5, 0}, 19936288.579, 0.001},
+		{"geodesic along the equator near the antipode", ArcTypeGeodesic, []float64{0, 0, 0, 179.7, 0, 0}, 19995624.890, 0.001},
+		{"geodesic to the antipode over a pole", ArcTypeGeodesic, []float64{0, 10, 0, 180, -10, 0}, 20003931.459, 0.001},
+		{"geodesic to the antipode on the equator", ArcTypeGeodesic, []float64{0, 0, 0, 180, 0, 0}, 20003931.459, 0.001},
+		{"unset arc type is geodesic", "", []float64{0, 0, 0, 0, 1, 0}, 110574.389, 0.001},
+		{"rhumb along the equator", ArcTypeRhumb, []float64{0, 0, 0, 1, 0, 0}, 111319.491, 0.001},
+		{"rhumb along a meridian", ArcTypeRhumb, []float64{0, 0, 0, 0, 1, 0}, 110574.389, 0.001},
+		{"rhumb from the equator to the pole", ArcTypeRhumb, []float64{10, 0, 0, 10, 90, 0}, 10001965.729, 0.001},
+		{"rhumb along the 45th parallel", ArcTypeRhumb, []float64{0, 45, 0, 1, 45, 0}, 78846.835, 0.001},
+		{"rhumb just off the 45th parallel", ArcTypeRhumb, []float64{0, 45, 0, 1, 45 + 1e-10, 0}, 78846.835, 0.001},
+		{"rhumb across the antimeridian", ArcTypeRhumb, []float64{179.5, 0, 0, -179.5, 0, 0}, 111319.491, 0.001},
+		{"rhumb over several segments", ArcTypeRhumb, []float64{0, 0, 0, 1, 0, 0, 1, 1, 0}, 111319.491 + 110574.389, 0.002},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := &PositionList{CartographicDegrees: tt.positions}
+			got, err := list.Length(tt.arcType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("length is %.4f m, want %.4f m", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVincentyInverseAzimuth(t *testing.T) {
+	rad := math.Pi / 180
+	_, azimuth, err := vincentyInverse(dms(144, 25, 29.52440)*rad, dms(-37, 57, 3.72030)*rad,
+		dms(143, 55, 35.38390)*rad, dms(-37, 39, 10.15610)*rad)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := math.Mod(azimuth/rad+360, 360)
+	if want := dms(306, 52, 5.37); math.Abs(got-want) > 0.01/3600 {
+		t.Errorf("azimuth is %.6f°, want %.6f°", got, want)
+	}
+}
+
+func TestRhumbNearlyEastWest(t *testing.T) {
+	rad := math.Pi / 180
+	parallel := rhumbLine(0, 45*rad, 1*rad, 45*rad).length
+
+	// lines a tiny fraction of a degree off east and west have nearly the length along the parallel
+	for _, dLat := range []float64{1e-15, 1e-13, 1e-11, 1e-9, 1e-7} {
+		s := rhumbLine(0, 45*rad, 1*rad, (45+dLat)*rad)
+		if math.Abs(s.length-parallel) > 0.001 {
+			t.Errorf("length %g° off the parallel is %.6f m, want %.6f m", dLat, s.length, parallel)
+		}
+	}
+
+	// and lines further off still end at their last position
+	for _, dLat := range []float64{1e-15, 1e-9, 1e-5, 1e-4, 1e-3, 0.1} {
+		s := rhumbLine(0, 45*rad, 1*rad, (45+dLat)*rad)
+		lon, lat := s.at(s.length)
+		if math.Abs(lon-1*rad) > 1e-9 || math.Abs(lat-(45+dLat)*rad) > 1e-9 {
+			t.Errorf("end %g° off the parallel is at %.9f°, %.9f°", dLat, lon/rad, lat/rad)
+		}
+	}
+}
+
+func TestDensifyStaysOnTheLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		arcType   ArcTypeValue
+		positions []float64
+	}{
+		{"geodesic", ArcTypeGeodesic, []float64{-73.78, 40.64, 0, -0.46, 51.47, 1000}},
+		{"rhumb", ArcTypeRhumb, []float64{-73.78, 40.64, 0, -0.46, 51.47, 1000}},
+		{"geodesic nearly antipodal", ArcTypeGeodesic, []float64{0, 0, 0, 179.7, 0, 0}},
+		{"geodesic antipodal over a pole", ArcTypeGeodesic, []float64{0, 10, 0, 180, -10, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := &PositionList{CartographicDegrees: tt.positions}
+			length, err := list.Length(tt.arcType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dense, err := list.Densify(tt.arcType, Spacing{Meters: 100000})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(dense.CartographicDegrees) / 3; n != int(math.Ceil(length/100000))+1 {
+				t.Errorf("densified to %d positions for %.0f m", n, length)
+			}
+
+			// the densified line has the same length as the original one
+			denseLength, err := dense.Length(tt.arcType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(denseLength-length) > 0.01 {
+				t.Errorf("densified length is %.3f m, want %.3f m", denseLength, length)
+			}
+		})
+	}
+}
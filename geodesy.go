@@ -0,0 +1,303 @@
+package czml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// WGS84 ellipsoid parameters
+const (
+	wgs84A  = 6378137.0
+	wgs84F  = 1 / 298.257223563
+	wgs84B  = wgs84A * (1 - wgs84F)
+	wgs84E2 = wgs84F * (2 - wgs84F)
+)
+
+// CartographicToCartesian converts a WGS84 geodetic position, with longitude and latitude in
+// radians and height in meters, to Earth-fixed Cartesian coordinates in meters
+func CartographicToCartesian(lon, lat, height float64) [3]float64 {
+	sinLat, cosLat := math.Sincos(lat)
+	sinLon, cosLon := math.Sincos(lon)
+	n := wgs84A / math.Sqrt(1-wgs84E2*sinLat*sinLat)
+
+	return [3]float64{
+		(n + height) * cosLat * cosLon,
+		(n + height) * cosLat * sinLon,
+		(n*(1-wgs84E2) + height) * sinLat,
+	}
+}
+
+// CartesianToCartographic converts Earth-fixed Cartesian coordinates in meters to a WGS84 geodetic
+// position, with longitude and latitude in radians and height in meters, using Heikkinen's exact
+// closed form
+func CartesianToCartographic(c [3]float64) (lon, lat, height float64) {
+	x, y, z := c[0], c[1], c[2]
+	a2 := wgs84A * wgs84A
+	b2 := wgs84B * wgs84B
+	ep2 := (a2 - b2) / b2
+
+	p := math.Hypot(x, y)
+	if p < 1e-9 {
+		// on the polar axis
+		lat = math.Copysign(math.Pi/2, z)
+		return 0, lat, math.Abs(z) - wgs84B
+	}
+
+	f := 54 * b2 * z * z
+	g := p*p + (1-wgs84E2)*z*z - wgs84E2*(a2-b2)
+	cc := wgs84E2 * wgs84E2 * f * p * p / (g * g * g)
+	s := math.Cbrt(1 + cc + math.Sqrt(cc*cc+2*cc))
+	k := s + 1 + 1/s
+	pp := f / (3 * k * k * g * g)
+	q := math.Sqrt(1 + 2*wgs84E2*wgs84E2*pp)
+	r0 := -(pp*wgs84E2*p)/(1+q) +
+		math.Sqrt(math.Max(0, a2/2*(1+1/q)-pp*(1-wgs84E2)*z*z/(q*(1+q))-pp*p*p/2))
+	u := math.Hypot(p-wgs84E2*r0, z)
+	v := math.Sqrt((p-wgs84E2*r0)*(p-wgs84E2*r0) + (1-wgs84E2)*z*z)
+	z0 := b2 * z / (wgs84A * v)
+
+	height = u * (1 - b2/(wgs84A*v))
+	lat = math.Atan2(z+ep2*z0, p)
+	lon = math.Atan2(y, x)
+
+	return lon, lat, height
+}
+
+// Representation names one of the forms a position can be given in
+type Representation string
+
+const (
+	RepresentationCartesian           Representation = "cartesian"
+	RepresentationCartographicRadians Representation = "cartographicRadians"
+	RepresentationCartographicDegrees Representation = "cartographicDegrees"
+)
+
+// convertValues applies a conversion to every position of a list holding positions of three values
+func convertValues(list []float64, convert func(a, b, c float64) (float64, float64, float64)) []float64 {
+	if list == nil {
+		return nil
+	}
+
+	result := make([]float64, len(list))
+	for i := 0; i+2 < len(list); i += 3 {
+		result[i], result[i+1], result[i+2] = convert(list[i], list[i+1], list[i+2])
+	}
+
+	return result
+}
+
+// convertSampled applies a conversion to a constant or to every sample, keeping sample times
+func convertSampled(v SampledValue, convert func(a, b, c float64) (float64, float64, float64)) SampledValue {
+	result := SampledValue{Value: convertValues(v.Value, convert)}
+	for _, s := range v.Samples {
+		result.Samples = append(result.Samples, Sample{Time: s.Time, Value: convertValues(s.Value, convert)})
+	}
+
+	return result
+}
+
+func degreesToRadians(lon, lat, height float64) (float64, float64, float64) {
+	return lon * math.Pi / 180, lat * math.Pi / 180, height
+}
+
+func radiansToDegrees(lon, lat, height float64) (float64, float64, float64) {
+	return lon * 180 / math.Pi, lat * 180 / math.Pi, height
+}
+
+func radiansToCartesian(lon, lat, height float64) (float64, float64, float64) {
+	c := CartographicToCartesian(lon, lat, height)
+	return c[0], c[1], c[2]
+}
+
+func degreesToCartesian(lon, lat, height float64) (float64, float64, float64) {
+	return radiansToCartesian(degreesToRadians(lon, lat, height))
+}
+
+func cartesianToRadians(x, y, z float64) (float64, float64, float64) {
+	return CartesianToCartographic([3]float64{x, y, z})
+}
+
+func cartesianToDegrees(x, y, z float64) (float64, float64, float64) {
+	return radiansToDegrees(cartesianToRadians(x, y, z))
+}
+
+// Radians returns the value with longitude and latitude in radians
+func (v CartographicDegreesValue) Radians() *CartographicRadiansValue {
+	return &CartographicRadiansValue{convertSampled(v.SampledValue, degreesToRadians)}
+}
+
+// Cartesian returns the value in Earth-fixed Cartesian coordinates
+func (v CartographicDegreesValue) Cartesian() *Cartesian3Value {
+	return &Cartesian3Value{convertSampled(v.SampledValue, degreesToCartesian)}
+}
+
+// Degrees returns the value with longitude and latitude in degrees
+func (v CartographicRadiansValue) Degrees() *CartographicDegreesValue {
+	return &CartographicDegreesValue{convertSampled(v.SampledValue, radiansToDegrees)}
+}
+
+// Cartesian returns the value in Earth-fixed Cartesian coordinates
+func (v CartographicRadiansValue) Cartesian() *Cartesian3Value {
+	return &Cartesian3Value{convertSampled(v.SampledValue, radiansToCartesian)}
+}
+
+// CartographicRadians returns the WGS84 geodetic value, with longitude and latitude in radians
+func (v Cartesian3Value) CartographicRadians() *CartographicRadiansValue {
+	return &CartographicRadiansValue{convertSampled(v.SampledValue, cartesianToRadians)}
+}
+
+// CartographicDegrees returns the WGS84 geodetic value, with longitude and latitude in degrees
+func (v Cartesian3Value) CartographicDegrees() *CartographicDegreesValue {
+	return &CartographicDegreesValue{convertSampled(v.SampledValue, cartesianToDegrees)}
+}
+
+// Radians returns the list with longitudes and latitudes in radians
+func (l CartographicDegreesListValue) Radians() CartographicRadiansListValue {
+	return convertValues(l, degreesToRadians)
+}
+
+// Cartesian returns the list in Earth-fixed Cartesian coordinates
+func (l CartographicDegreesListValue) Cartesian() Cartesian3ListValue {
+	return convertValues(l, degreesToCartesian)
+}
+
+// Degrees returns the list with longitudes and latitudes in degrees
+func (l CartographicRadiansListValue) Degrees() CartographicDegreesListValue {
+	return convertValues(l, radiansToDegrees)
+}
+
+// Cartesian returns the list in Earth-fixed Cartesian coordinates
+func (l CartographicRadiansListValue) Cartesian() Cartesian3ListValue {
+	return convertValues(l, radiansToCartesian)
+}
+
+// CartographicRadians returns the WGS84 geodetic list, with longitudes and latitudes in radians
+func (l Cartesian3ListValue) CartographicRadians() CartographicRadiansListValue {
+	return convertValues(l, cartesianToRadians)
+}
+
+// CartographicDegrees returns the WGS84 geodetic list, with longitudes and latitudes in degrees
+func (l Cartesian3ListValue) CartographicDegrees() CartographicDegreesListValue {
+	return convertValues(l, cartesianToDegrees)
+}
+
+// As returns a copy of the position expressed in another representation, keeping sample times and
+// interpolation options. Velocities are dropped when a cartesianVelocity position is converted.
+// References and positions in the INERTIAL reference frame cannot be converted.
+func (p *Position) As(r Representation) (*Position, error) {
+	if p.ReferenceFrame == "INERTIAL" {
+		return nil, errors.New("czml: positions in the INERTIAL reference frame cannot be converted")
+	}
+	if p.Reference != "" {
+		return nil, errors.New("czml: a reference cannot be converted")
+	}
+
+	var cartesian *Cartesian3Value
+	switch {
+	case p.Cartesian != nil:
+		cartesian = p.Cartesian
+	case p.CartesianVelocity != nil:
+		cartesian = &Cartesian3Value{dropVelocity(p.CartesianVelocity.SampledValue)}
+	case p.CartographicRadians != nil:
+		cartesian = p.CartographicRadians.Cartesian()
+	case p.CartographicDegrees != nil:
+		cartesian = p.CartographicDegrees.Cartesian()
+	default:
+		return nil, errors.New("czml: position has no value to convert")
+	}
+
+	result := &Position{ReferenceFrame: p.ReferenceFrame}
+	result.Interpolatable = deepCopy(reflect.ValueOf(p.Interpolatable)).Interface().(Interpolatable)
+	switch r {
+	case RepresentationCartesian:
+		result.Cartesian = deepCopy(reflect.ValueOf(cartesian)).Interface().(*Cartesian3Value)
+	case RepresentationCartographicRadians:
+		if p.CartographicRadians != nil {
+			result.CartographicRadians = deepCopy(reflect.ValueOf(p.CartographicRadians)).Interface().(*CartographicRadiansValue)
+		} else if p.CartographicDegrees != nil {
+			result.CartographicRadians = p.CartographicDegrees.Radians()
+		} else {
+			result.CartographicRadians = cartesian.CartographicRadians()
+		}
+	case RepresentationCartographicDegrees:
+		if p.CartographicDegrees != nil {
+			result.CartographicDegrees = deepCopy(reflect.ValueOf(p.CartographicDegrees)).Interface().(*CartographicDegreesValue)
+		} else if p.CartographicRadians != nil {
+			result.CartographicDegrees = p.CartographicRadians.Degrees()
+		} else {
+			result.CartographicDegrees = cartesian.CartographicDegrees()
+		}
+	default:
+		return nil, fmt.Errorf("czml: cannot convert a position to %q", r)
+	}
+
+	return result, nil
+}
+
+// As returns a copy of the list expressed in another representation. References and lists in the
+// INERTIAL reference frame cannot be converted.
+func (p *PositionList) As(r Representation) (*PositionList, error) {
+	if p.ReferenceFrame == "INERTIAL" {
+		return nil, errors.New("czml: positions in the INERTIAL reference frame cannot be converted")
+	}
+
+	if p.References != nil {
+		return nil, errors.New("czml: references cannot be converted")
+	}
+
+	var cartesian Cartesian3ListValue
+	switch {
+	case p.Cartesian != nil:
+		cartesian = *p.Cartesian
+	case p.CartographicRadians != nil:
+		cartesian = p.CartographicRadians.Cartesian()
+	case p.CartographicDegrees != nil:
+		cartesian = p.CartographicDegrees.Cartesian()
+	default:
+		return nil, errors.New("czml: position list has no value to convert")
+	}
+
+	result := &PositionList{ReferenceFrame: p.ReferenceFrame}
+	switch r {
+	case RepresentationCartesian:
+		list := append(Cartesian3ListValue(nil), cartesian...)
+		result.Cartesian = &list
+	case RepresentationCartographicRadians:
+		var list CartographicRadiansListValue
+		if p.CartographicRadians != nil {
+			list = append(list, *p.CartographicRadians...)
+		} else if p.CartographicDegrees != nil {
+			list = p.CartographicDegrees.Radians()
+		} else {
+			list = cartesian.CartographicRadians()
+		}
+		result.CartographicRadians = &list
+	case RepresentationCartographicDegrees:
+		if p.CartographicDegrees != nil {
+			result.CartographicDegrees = append(CartographicDegreesListValue(nil), p.CartographicDegrees...)
+		} else if p.CartographicRadians != nil {
+			result.CartographicDegrees = p.CartographicRadians.Degrees()
+		} else {
+			result.CartographicDegrees = cartesian.CartographicDegrees()
+		}
+	default:
+		return nil, fmt.Errorf("czml: cannot convert a position list to %q", r)
+	}
+
+	return result, nil
+}
+
+// dropVelocity returns the positions of a cartesianVelocity value
+func dropVelocity(v SampledValue) SampledValue {
+	result := SampledValue{}
+	if v.Value != nil {
+		result.Value = append([]float64(nil), v.Value[:3]...)
+	}
+	for _, s := range v.Samples {
+		result.Samples = append(result.Samples, Sample{Time: s.Time, Value: append([]float64(nil), s.Value[:3]...)})
+	}
+
+	return result
+}
@@ -0,0 +1,151 @@
+package czml
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCartographicToCartesian(t *testing.T) {
+	tests := []struct {
+		name             string
+		lon, lat, height float64
+		want             [3]float64
+	}{
+		{"equator", 0, 0, 0, [3]float64{wgs84A, 0, 0}},
+		{"equator above the surface", math.Pi / 2, 0, 100, [3]float64{0, wgs84A + 100, 0}},
+		{"north pole", 0, math.Pi / 2, 0, [3]float64{0, 0, 6356752.314245}},
+		{"south pole below the surface", 0, -math.Pi / 2, -100, [3]float64{0, 0, -6356652.314245}},
+		{"45 degrees", math.Pi, math.Pi / 4, 0, [3]float64{-4517590.878849, 0, 4487348.408866}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CartographicToCartesian(tt.lon, tt.lat, tt.height)
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-6 {
+					t.Fatalf("position is %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCartographicRoundTrip(t *testing.T) {
+	rad := math.Pi / 180
+	for _, lat := range []float64{-90, -89.999, -60, -1e-9, 0, 30, 45, 89.9999, 90} {
+		for _, lon := range []float64{-180, -90, 0, 1e-9, 45, 179.5} {
+			for _, height := range []float64{-5000, 0, 1, 8848, 400000, 36000000} {
+				gotLon, gotLat, gotHeight := CartesianToCartographic(CartographicToCartesian(lon*rad, lat*rad, height))
+
+				if math.Abs(gotLat-lat*rad) > 1e-11 || math.Abs(gotHeight-height) > 1e-5 {
+					t.Errorf("%g°, %g°, %g m is read back as %g°, %g°, %g m", lon, lat, height, gotLon/rad, gotLat/rad, gotHeight)
+				}
+				// longitude is undefined on the polar axis
+				if math.Abs(lat) != 90 && math.Abs(math.Remainder(gotLon-lon*rad, 2*math.Pi)) > 1e-11 {
+					t.Errorf("%g°, %g°, %g m is read back at longitude %g°", lon, lat, height, gotLon/rad)
+				}
+			}
+		}
+	}
+}
+
+// closeSamples reports whether two values have the same sample times and nearly the same values
+func closeSamples(a, b SampledValue, tolerance float64) bool {
+	closeValues := func(x, y []float64) bool {
+		if len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if math.Abs(x[i]-y[i]) > tolerance {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !closeValues(a.Value, b.Value) || len(a.Samples) != len(b.Samples) {
+		return false
+	}
+	for i := range a.Samples {
+		if a.Samples[i].Time != b.Samples[i].Time || !closeValues(a.Samples[i].Value, b.Samples[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestPositionAs(t *testing.T) {
+	const (
+		sampledDegrees = `{"epoch":"2020-01-01T00:00:00Z","interpolationAlgorithm":"LAGRANGE","interpolationDegree":5,` +
+			`"cartographicDegrees":[0,90,0,100,"2020-01-01T00:01:00Z",180,45,0]}`
+		sampledCartesian = `{"cartesian":[0,0,6378237,0,60,-4517590.878849,0,4487348.408866]}`
+	)
+
+	tests := []struct {
+		name           string
+		position       string
+		representation Representation
+		want           string
+		tolerance      float64
+		err            string
+	}{
+		{"degrees to radians", sampledDegrees, RepresentationCartographicRadians,
+			`{"cartographicRadians":[0,1.5707963267948966,0,100,"2020-01-01T00:01:00Z",3.141592653589793,0.7853981633974483,0]}`, 1e-15, ""},
+		{"degrees to cartesian", sampledDegrees, RepresentationCartesian,
+			`{"cartesian":[0,0,6378237,0,"2020-01-01T00:01:00Z",-4517590.878849,0,4487348.408866]}`, 1e-6, ""},
+		{"cartesian to degrees", sampledCartesian, RepresentationCartographicDegrees,
+			`{"cartographicDegrees":[0,90,0,100,60,180,45,0]}`, 1e-6, ""},
+		{"velocities dropped", `{"cartesianVelocity":[0,0,6378137,0,1,0,0,60,0,6378137,0,1,0,0]}`, RepresentationCartographicDegrees,
+			`{"cartographicDegrees":[0,90,0,0,60,90,0,0]}`, 1e-6, ""},
+		{"constant", `{"cartographicDegrees":[90,0,100]}`, RepresentationCartesian, `{"cartesian":[0,6378237,0]}`, 1e-6, ""},
+		{"same representation", sampledCartesian, RepresentationCartesian, sampledCartesian, 0, ""},
+		{"inertial", `{"referenceFrame":"INERTIAL","cartesian":[1,2,3]}`, RepresentationCartographicDegrees, "", 0, "INERTIAL"},
+		{"reference", `{"reference":"e#position"}`, RepresentationCartesian, "", 0, "reference"},
+		{"unknown representation", `{"cartesian":[1,2,3]}`, "spherical", "", 0, "cannot convert"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Position
+			if err := json.Unmarshal([]byte(tt.position), &p); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := p.As(tt.representation)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error is %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want Position
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			want.Interpolatable = p.Interpolatable
+
+			var gotValue, wantValue SampledValue
+			switch tt.representation {
+			case RepresentationCartesian:
+				gotValue, wantValue = got.Cartesian.SampledValue, want.Cartesian.SampledValue
+			case RepresentationCartographicRadians:
+				gotValue, wantValue = got.CartographicRadians.SampledValue, want.CartographicRadians.SampledValue
+			case RepresentationCartographicDegrees:
+				gotValue, wantValue = got.CartographicDegrees.SampledValue, want.CartographicDegrees.SampledValue
+			}
+			if !closeSamples(gotValue, wantValue, tt.tolerance) {
+				t.Errorf("position is %s, want %s", packetJSON(t, got), tt.want)
+			}
+			if packetJSON(t, got.Interpolatable) != packetJSON(t, want.Interpolatable) {
+				t.Errorf("interpolation is %s, want %s", packetJSON(t, got.Interpolatable), packetJSON(t, want.Interpolatable))
+			}
+		})
+	}
+}
@@ -0,0 +1,730 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeatureCollection is a GeoJSON feature collection, as defined by RFC 7946. Name is the name
+// written by tools such as GDAL, and is used as the name of the document.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Name     string    `json:"name,omitempty"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON feature. Id is a string or a number when it is set.
+type Feature struct {
+	Type       string                 `json:"type"`
+	Id         interface{}            `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a GeoJSON geometry. Coordinates are kept as JSON, since their nesting depends on
+// the type.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []Geometry      `json:"geometries,omitempty"`
+}
+
+// StyleFunc sets the display properties of a packet made from a feature, such as its Material or
+// Label. It is called once for every packet holding a geometry, including each part of a Multi*
+// geometry, after the geometry is set.
+type StyleFunc func(f Feature, p *Packet)
+
+// ParseGeoJSON reads a GeoJSON feature collection and converts it with FromGeoJSON
+func ParseGeoJSON(data []byte, style StyleFunc) (Czml, error) {
+	var fc FeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return Czml{}, err
+	}
+
+	return FromGeoJSON(fc, style)
+}
+
+// FromGeoJSON returns a Czml with a packet for each feature, after the document packet. A Point is
+// given a point, a LineString a polyline, and a Polygon a polygon with its interior rings as holes.
+// The parts of Multi* geometries and geometry collections become children of the feature's packet.
+// Feature ids are used as packet ids, and features without one are numbered "feature-0",
+// "feature-1" and so on. Feature properties are copied to the packet properties, and the "name"
+// property is used as the packet name. Arrays are written as {"array": [...]} and objects as JSON
+// strings, since Cesium would read them as intervals and properties. style may be nil.
+func FromGeoJSON(fc FeatureCollection, style StyleFunc) (Czml, error) {
+	if fc.Type != "FeatureCollection" {
+		return Czml{}, fmt.Errorf("czml: GeoJSON type is %q, not FeatureCollection", fc.Type)
+	}
+
+	var c Czml
+	c.InitializeDocument(fc.Name)
+
+	for i, f := range fc.Features {
+		p := Packet{Id: featureId(f, i)}
+		if name, ok := f.Properties["name"].(string); ok {
+			p.Name = name
+		}
+		if f.Properties != nil {
+			properties := CustomProperties{}
+			for k, v := range f.Properties {
+				value, err := customPropertyValue(v)
+				if err != nil {
+					return Czml{}, fmt.Errorf("czml: feature %d: property %q: %w", i, k, err)
+				}
+				properties[k] = value
+			}
+			p.Properties = &properties
+		}
+
+		if f.Geometry == nil {
+			c.AddPacket(p)
+			continue
+		}
+
+		parts, err := geometryParts(*f.Geometry)
+		if err != nil {
+			return Czml{}, fmt.Errorf("czml: feature %d: %w", i, err)
+		}
+
+		if len(parts) == 1 && parts[0].Type == f.Geometry.Type {
+			if err := setGeometry(&p, parts[0]); err != nil {
+				return Czml{}, fmt.Errorf("czml: feature %d: %w", i, err)
+			}
+			if style != nil {
+				style(f, &p)
+			}
+			c.AddPacket(p)
+			continue
+		}
+
+		c.AddPacket(p)
+		for j, part := range parts {
+			child := Packet{Id: p.Id + "/" + strconv.Itoa(j), Name: p.Name, Parent: p.Id}
+			if err := setGeometry(&child, part); err != nil {
+				return Czml{}, fmt.Errorf("czml: feature %d: %w", i, err)
+			}
+			if style != nil {
+				style(f, &child)
+			}
+			c.AddPacket(child)
+		}
+	}
+
+	return c, nil
+}
+
+// customPropertyValue returns a GeoJSON property value in a form Cesium keeps as data
+func customPropertyValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		return map[string]interface{}{"array": v}, nil
+	case map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+
+	return v, nil
+}
+
+func featureId(f Feature, index int) string {
+	switch id := f.Id.(type) {
+	case string:
+		return id
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	case json.Number:
+		return id.String()
+	}
+
+	return "feature-" + strconv.Itoa(index)
+}
+
+// geometryParts splits Multi* geometries and geometry collections into Points, LineStrings and
+// Polygons
+func geometryParts(g Geometry) ([]Geometry, error) {
+	switch g.Type {
+	case "Point", "LineString", "Polygon":
+		return []Geometry{g}, nil
+	case "MultiPoint", "MultiLineString", "MultiPolygon":
+		var coordinates []json.RawMessage
+		if err := json.Unmarshal(g.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("%s coordinates: %w", g.Type, err)
+		}
+
+		parts := make([]Geometry, len(coordinates))
+		for i, c := range coordinates {
+			parts[i] = Geometry{Type: g.Type[len("Multi"):], Coordinates: c}
+		}
+		return parts, nil
+	case "GeometryCollection":
+		var parts []Geometry
+		for _, member := range g.Geometries {
+			p, err := geometryParts(member)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, p...)
+		}
+		return parts, nil
+	}
+
+	return nil, fmt.Errorf("unknown geometry type %q", g.Type)
+}
+
+// setGeometry gives a packet the graphics for a Point, LineString or Polygon
+func setGeometry(p *Packet, g Geometry) error {
+	switch g.Type {
+	case "Point":
+		var position []float64
+		if err := json.Unmarshal(g.Coordinates, &position); err != nil {
+			return fmt.Errorf("Point coordinates: %w", err)
+		}
+		positions, _, err := geoJSONPositions([][]float64{position})
+		if err != nil {
+			return err
+		}
+		p.Position = NewProperty(Position{CartographicDegrees: &CartographicDegreesValue{SampledValue{Value: positions}}})
+		p.Point = &Point{}
+	case "LineString":
+		var line [][]float64
+		if err := json.Unmarshal(g.Coordinates, &line); err != nil {
+			return fmt.Errorf("LineString coordinates: %w", err)
+		}
+		positions, _, err := geoJSONPositions(line)
+		if err != nil {
+			return err
+		}
+		p.Polyline = &Polyline{Positions: NewProperty(PositionList{CartographicDegrees: positions})}
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+			return fmt.Errorf("Polygon coordinates: %w", err)
+		}
+		if len(rings) == 0 {
+			return errors.New("Polygon has no rings")
+		}
+
+		polygon := &Polygon{}
+		var holes CartographicDegreesListOfListsValue
+		heights := false
+		for i, ring := range rings {
+			// the last position of a ring repeats the first
+			if len(ring) > 1 {
+				ring = ring[:len(ring)-1]
+			}
+			positions, h, err := geoJSONPositions(ring)
+			if err != nil {
+				return err
+			}
+			heights = heights || h
+
+			if i == 0 {
+				polygon.Positions = NewProperty(PositionList{CartographicDegrees: positions})
+			} else {
+				holes = append(holes, positions)
+			}
+		}
+		if holes != nil {
+			polygon.Holes = NewProperty(PositionListOfLists{CartographicDegrees: &holes})
+		}
+		if heights {
+			polygon.PerPositionHeight = NewProperty(true)
+		}
+		p.Polygon = polygon
+	default:
+		return fmt.Errorf("unknown geometry type %q", g.Type)
+	}
+
+	return nil
+}
+
+// geoJSONPositions returns GeoJSON positions as cartographic degrees, with a height of zero for
+// positions without one, and whether any position has a height
+func geoJSONPositions(positions [][]float64) (CartographicDegreesListValue, bool, error) {
+	list := make(CartographicDegreesListValue, 0, 3*len(positions))
+	heights := false
+	for _, p := range positions {
+		switch len(p) {
+		case 2:
+			list = append(list, p[0], p[1], 0)
+		case 3, 4:
+			// a fourth value, such as a measure, is not part of the position
+			list = append(list, p[0], p[1], p[2])
+			heights = true
+		default:
+			return nil, false, fmt.Errorf("position has %d values", len(p))
+		}
+	}
+
+	return list, heights, nil
+}
+
+// ErrNoGeometry is the reason a packet is skipped by ToGeoJSON when it has nothing GeoJSON can
+// represent, such as the document packet or a folder
+var ErrNoGeometry = errors.New("czml: packet has no geometry GeoJSON can represent")
+
+// SkippedPacket describes a packet that ToGeoJSON could not turn into a feature
+type SkippedPacket struct {
+	Id  string // id of the packet, empty for packets without one
+	Err error
+}
+
+func (s SkippedPacket) Error() string {
+	return fmt.Sprintf("czml: packet %q: %s", s.Id, strings.TrimPrefix(s.Err.Error(), "czml: "))
+}
+
+func (s SkippedPacket) Unwrap() error {
+	return s.Err
+}
+
+// ToGeoJSON returns a feature for each entity of c, evaluated at a time. Packets sharing an id are
+// merged first. A position becomes a Point, the positions of a polyline or wall a LineString, and a
+// polygon, rectangle or corridor a Polygon, with a GeometryCollection for entities with several of
+// them. Children holding a geometry without properties of their own, such as the parts made by
+// FromGeoJSON, are gathered into the feature of their parent, as a Multi* geometry when they share
+// a type. Properties use the value of the interval active at the time, following references, and
+// corridors are outlined with rounded ends. Feature properties hold the entity's custom
+// properties, and its name and description when they are set. Packets that cannot be merged, and
+// entities that are not available at the time or that cannot be represented, are returned as
+// skipped packets rather than features. The document packet is used for the name of the
+// collection.
+func ToGeoJSON(c Czml, at time.Time) (FeatureCollection, []SkippedPacket) {
+	fc := FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+	var skipped []SkippedPacket
+
+	compacted := compact(c, func(p Packet, err error) {
+		skipped = append(skipped, SkippedPacket{Id: p.Id, Err: err})
+	})
+	resolver := NewResolver(compacted)
+	parts, gathered := geometryParents(compacted)
+
+	for _, p := range compacted.Packets {
+		if p.Id == "document" {
+			fc.Name = p.Name
+			continue
+		}
+		if gathered[p.Id] {
+			continue
+		}
+
+		geometries, err := packetGeometries(p, at, resolver)
+		if err != nil {
+			skipped = append(skipped, SkippedPacket{Id: p.Id, Err: err})
+			continue
+		}
+
+		var partGeometries []Geometry
+		for _, part := range parts[p.Id] {
+			g, err := packetGeometries(part, at, resolver)
+			if err != nil {
+				skipped = append(skipped, SkippedPacket{Id: part.Id, Err: err})
+				continue
+			}
+			partGeometries = append(partGeometries, g...)
+		}
+
+		geometry, err := featureGeometry(geometries, partGeometries)
+		if err != nil {
+			skipped = append(skipped, SkippedPacket{Id: p.Id, Err: err})
+			continue
+		}
+		fc.Features = append(fc.Features, packetFeature(p, geometry))
+	}
+
+	return fc, skipped
+}
+
+// geometryParents returns the children gathered into the feature of each parent, and the ids of
+// the gathered children. A child is gathered when it holds a geometry, has no children, and has
+// no properties of its own: no custom properties or description, and no name or its parent's.
+func geometryParents(c Czml) (map[string][]Packet, map[string]bool) {
+	packets := map[string]Packet{}
+	parents := map[string]bool{}
+	for _, p := range c.Packets {
+		if p.Id != "" {
+			packets[p.Id] = p
+		}
+		if p.Parent != "" {
+			parents[p.Parent] = true
+		}
+	}
+
+	parts := map[string][]Packet{}
+	gathered := map[string]bool{}
+	for _, p := range c.Packets {
+		parent, ok := packets[p.Parent]
+		if !ok || p.Id == "" || parent.Id == "document" || parents[p.Id] {
+			continue
+		}
+		if !hasGeometry(p) || p.Properties != nil || p.Description != "" || (p.Name != "" && p.Name != parent.Name) {
+			continue
+		}
+		parts[parent.Id] = append(parts[parent.Id], p)
+		gathered[p.Id] = true
+	}
+
+	return parts, gathered
+}
+
+// hasGeometry reports whether a packet holds something ToGeoJSON turns into a geometry
+func hasGeometry(p Packet) bool {
+	return p.Position != nil ||
+		(p.Polyline != nil && p.Polyline.Positions != nil) ||
+		(p.Wall != nil && p.Wall.Positions != nil) ||
+		(p.Polygon != nil && p.Polygon.Positions != nil) ||
+		(p.Rectangle != nil && p.Rectangle.Coordinates != nil) ||
+		(p.Corridor != nil && p.Corridor.Positions != nil)
+}
+
+// packetGeometries returns the geometries of an entity at a time
+func packetGeometries(p Packet, at time.Time, resolver *Resolver) ([]Geometry, error) {
+	if p.Availability != nil && !p.Availability.Contains(at) {
+		return nil, fmt.Errorf("not available at %s", NewJulianDate(at))
+	}
+
+	var geometries []Geometry
+	add := func(typ string, coordinates interface{}) error {
+		data, err := json.Marshal(coordinates)
+		if err != nil {
+			return err
+		}
+		geometries = append(geometries, Geometry{Type: typ, Coordinates: data})
+		return nil
+	}
+
+	if p.Position != nil {
+		position, err := positionDegreesAt(p.Id, p.Position, at, resolver)
+		if err != nil {
+			return nil, geometryError{"position", err}
+		}
+		if err := add("Point", position); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Polyline != nil && p.Polyline.Positions != nil {
+		line, err := positionsDegreesAt(p.Id, p.Polyline.Positions, at, resolver)
+		if err != nil {
+			return nil, geometryError{"polyline", err}
+		}
+		if err := add("LineString", line); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Wall != nil && p.Wall.Positions != nil {
+		line, err := positionsDegreesAt(p.Id, p.Wall.Positions, at, resolver)
+		if err != nil {
+			return nil, geometryError{"wall", err}
+		}
+		if err := add("LineString", line); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Polygon != nil && p.Polygon.Positions != nil {
+		rings, err := polygonRings(p.Id, p.Polygon, at, resolver)
+		if err != nil {
+			return nil, geometryError{"polygon", err}
+		}
+		if err := add("Polygon", rings); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Rectangle != nil && p.Rectangle.Coordinates != nil {
+		ring, err := rectangleRing(p.Id, p.Rectangle.Coordinates, at, resolver)
+		if err != nil {
+			return nil, geometryError{"rectangle", err}
+		}
+		if err := add("Polygon", [][][]float64{ring}); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Corridor != nil && p.Corridor.Positions != nil {
+		ring, err := corridorRing(p.Id, p.Corridor, at, resolver)
+		if err != nil {
+			return nil, geometryError{"corridor", err}
+		}
+		if err := add("Polygon", [][][]float64{ring}); err != nil {
+			return nil, err
+		}
+	}
+
+	return geometries, nil
+}
+
+// featureGeometry returns the geometry of a feature from the geometries of its entity and of the
+// children gathered into it. Children sharing a type become a Multi* geometry.
+func featureGeometry(geometries, parts []Geometry) (*Geometry, error) {
+	if len(geometries) == 0 && len(parts) > 0 {
+		coordinates := make([]json.RawMessage, len(parts))
+		for i, g := range parts {
+			if g.Type != parts[0].Type {
+				return &Geometry{Type: "GeometryCollection", Geometries: parts}, nil
+			}
+			coordinates[i] = g.Coordinates
+		}
+
+		data, err := json.Marshal(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		return &Geometry{Type: "Multi" + parts[0].Type, Coordinates: data}, nil
+	}
+
+	geometries = append(geometries, parts...)
+	switch len(geometries) {
+	case 0:
+		return nil, ErrNoGeometry
+	case 1:
+		return &geometries[0], nil
+	}
+
+	return &Geometry{Type: "GeometryCollection", Geometries: geometries}, nil
+}
+
+// packetFeature returns the feature for an entity with its geometry
+func packetFeature(p Packet, geometry *Geometry) Feature {
+	f := Feature{Type: "Feature", Geometry: geometry, Properties: map[string]interface{}{}}
+	if p.Id != "" {
+		f.Id = p.Id
+	}
+
+	if p.Properties != nil {
+		for k, v := range *p.Properties {
+			// arrays are unwrapped, so features made by FromGeoJSON keep their properties
+			if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+				if array, ok := m["array"].([]interface{}); ok {
+					v = array
+				}
+			}
+			f.Properties[k] = v
+		}
+	}
+	if p.Name != "" {
+		f.Properties["name"] = p.Name
+	}
+	if p.Description != "" {
+		f.Properties["description"] = p.Description
+	}
+
+	return f
+}
+
+// geometryError is an error converting one of the geometries of a packet
+type geometryError struct {
+	geometry string
+	err      error
+}
+
+func (e geometryError) Error() string {
+	return e.geometry + ": " + strings.TrimPrefix(e.err.Error(), "czml: ")
+}
+
+func (e geometryError) Unwrap() error {
+	return e.err
+}
+
+// propertyAt returns the value of a property of the entity id at a time, following references.
+// name describes the value in errors.
+func propertyAt[T any](id string, p *Property[T], at time.Time, resolver *Resolver, name string) (T, error) {
+	var zero T
+	if ref, ok := referenceOf(reflect.ValueOf(p)); ok {
+		target, err := resolver.Resolve(id, ref)
+		if err != nil {
+			return zero, err
+		}
+		property, ok := target.(*Property[T])
+		if !ok {
+			return zero, fmt.Errorf("reference %q is not %s", string(ref), name)
+		}
+		p = property
+	}
+
+	v, ok := p.ValueAt(at)
+	if !ok {
+		return zero, ErrNoValue
+	}
+	return v, nil
+}
+
+// positionDegreesAt returns a position at a time as a GeoJSON position, following references
+func positionDegreesAt(id string, property *Property[Position], at time.Time, resolver *Resolver) ([]float64, error) {
+	p, err := propertyAt(id, property, at, resolver, "a position")
+	if err != nil {
+		return nil, err
+	}
+	if p.ReferenceFrame == "INERTIAL" {
+		return nil, errors.New("positions in the INERTIAL reference frame cannot be converted")
+	}
+
+	value, err := p.ValueAt(at)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.CartesianVelocity != nil, p.Cartesian != nil:
+		lon, lat, height := CartesianToCartographic([3]float64{value[0], value[1], value[2]})
+		return []float64{lon * 180 / math.Pi, lat * 180 / math.Pi, height}, nil
+	case p.CartographicRadians != nil:
+		return []float64{value[0] * 180 / math.Pi, value[1] * 180 / math.Pi, value[2]}, nil
+	}
+
+	return []float64{value[0], value[1], value[2]}, nil
+}
+
+// positionsDegreesAt returns a position list at a time as GeoJSON positions, following references
+func positionsDegreesAt(id string, property *Property[PositionList], at time.Time, resolver *Resolver) ([][]float64, error) {
+	l, err := propertyAt(id, property, at, resolver, "a position list")
+	if err != nil {
+		return nil, err
+	}
+
+	return positionsDegrees(&l)
+}
+
+// positionsDegrees returns a position list as GeoJSON positions
+func positionsDegrees(l *PositionList) ([][]float64, error) {
+	if l.ReferenceFrame == "INERTIAL" {
+		return nil, errors.New("positions in the INERTIAL reference frame cannot be converted")
+	}
+	degrees, err := l.As(RepresentationCartographicDegrees)
+	if err != nil {
+		return nil, err
+	}
+
+	list := degrees.CartographicDegrees
+	positions := make([][]float64, 0, len(list)/3)
+	for i := 0; i+2 < len(list); i += 3 {
+		positions = append(positions, []float64{list[i], list[i+1], list[i+2]})
+	}
+
+	return positions, nil
+}
+
+// polygonRings returns the positions and holes of a polygon at a time as closed GeoJSON rings
+func polygonRings(id string, p *Polygon, at time.Time, resolver *Resolver) ([][][]float64, error) {
+	positions, err := propertyAt(id, p.Positions, at, resolver, "a position list")
+	if err != nil {
+		return nil, err
+	}
+
+	lists := []*PositionList{&positions}
+	if p.Holes != nil {
+		h, err := propertyAt(id, p.Holes, at, resolver, "a list of position lists")
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case h.Cartesian != nil:
+			for _, hole := range *h.Cartesian {
+				hole := hole
+				lists = append(lists, &PositionList{Cartesian: &hole})
+			}
+		case h.CartographicRadians != nil:
+			for _, hole := range *h.CartographicRadians {
+				hole := hole
+				lists = append(lists, &PositionList{CartographicRadians: &hole})
+			}
+		case h.CartographicDegrees != nil:
+			for _, hole := range *h.CartographicDegrees {
+				lists = append(lists, &PositionList{CartographicDegrees: hole})
+			}
+		case h.References != nil:
+			return nil, errors.New("references cannot be converted")
+		}
+	}
+
+	rings := make([][][]float64, len(lists))
+	for i, l := range lists {
+		l.ReferenceFrame = positions.ReferenceFrame
+		ring, err := positionsDegrees(l)
+		if err != nil {
+			return nil, err
+		}
+		rings[i] = closeRing(ring)
+	}
+
+	return rings, nil
+}
+
+// rectangleRing returns the corners of a rectangle at a time as a closed GeoJSON ring
+func rectangleRing(id string, property *Property[RectangleCoordinates], at time.Time, resolver *Resolver) ([][]float64, error) {
+	r, err := propertyAt(id, property, at, resolver, "rectangle coordinates")
+	if err != nil {
+		return nil, err
+	}
+
+	var wsen []float64
+	switch {
+	case r.WsenDegrees != nil:
+		wsen, err = r.Evaluate(r.WsenDegrees.SampledValue, at)
+	case r.Wsen != nil:
+		wsen, err = r.Evaluate(r.Wsen.SampledValue, at)
+		if err == nil {
+			wsen = []float64{wsen[0] * 180 / math.Pi, wsen[1] * 180 / math.Pi, wsen[2] * 180 / math.Pi, wsen[3] * 180 / math.Pi}
+		}
+	default:
+		return nil, ErrNoValue
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(wsen) != 4 {
+		return nil, fmt.Errorf("rectangle has %d coordinates", len(wsen))
+	}
+
+	w, s, e, n := wsen[0], wsen[1], wsen[2], wsen[3]
+	return [][]float64{{w, s}, {e, s}, {e, n}, {w, n}, {w, s}}, nil
+}
+
+// corridorRing returns the outline of a corridor at a time as a closed GeoJSON ring
+func corridorRing(id string, c *Corridor, at time.Time, resolver *Resolver) ([][]float64, error) {
+	if c.Width == nil {
+		return nil, errors.New("corridor has no width")
+	}
+	double, err := propertyAt(id, c.Width, at, resolver, "a number")
+	if err != nil {
+		return nil, err
+	}
+	width, err := double.ValueAt(at)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := propertyAt(id, c.Positions, at, resolver, "a position list")
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := NewBufferPolygon("", &positions, width/2, 32, nil)
+	if err != nil {
+		return nil, err
+	}
+	ring, err := positionsDegrees(&p.Polygon.Positions.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return closeRing(ring), nil
+}
+
+// closeRing repeats the first position of a ring at its end, as GeoJSON requires
+func closeRing(ring [][]float64) [][]float64 {
+	if len(ring) == 0 {
+		return ring
+	}
+
+	return append(ring, ring[0])
+}
@@ -0,0 +1,313 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGeoJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		feature string
+		id      string // the exported id, when it differs
+	}{
+		{"point", `{"type": "Feature", "id": "p", "properties": {"name": "Point", "rank": 1},
+			"geometry": {"type": "Point", "coordinates": [2.35, 48.85, 35]}}`, ""},
+		{"line string", `{"type": "Feature", "id": "l", "properties": {},
+			"geometry": {"type": "LineString", "coordinates": [[2.35, 48.85, 0], [-0.12, 51.5, 0], [4.9, 52.37, 0]]}}`, ""},
+		{"polygon with a hole", `{"type": "Feature", "id": "h", "properties": {"kind": "park"},
+			"geometry": {"type": "Polygon", "coordinates": [
+				[[0, 0, 0], [1, 0, 0], [1, 1, 0], [0, 1, 0], [0, 0, 0]],
+				[[0.2, 0.2, 0], [0.8, 0.2, 0], [0.8, 0.8, 0], [0.2, 0.2, 0]]]}}`, ""},
+		{"multi point", `{"type": "Feature", "id": "m", "properties": {"name": "Points", "kind": "stops"},
+			"geometry": {"type": "MultiPoint", "coordinates": [[1, 2, 0], [3, 4, 0]]}}`, ""},
+		{"multi line string", `{"type": "Feature", "id": "m", "properties": {},
+			"geometry": {"type": "MultiLineString", "coordinates": [[[0, 0, 0], [1, 1, 0]], [[2, 2, 0], [3, 3, 0]]]}}`, ""},
+		{"multi polygon", `{"type": "Feature", "id": "m", "properties": {"kind": "islands"},
+			"geometry": {"type": "MultiPolygon", "coordinates": [
+				[[[0, 0, 0], [1, 0, 0], [1, 1, 0], [0, 0, 0]]],
+				[[[2, 2, 0], [3, 2, 0], [3, 3, 0], [2, 2, 0]]]]}}`, ""},
+		{"geometry collection", `{"type": "Feature", "id": "g", "properties": {"kind": "site"},
+			"geometry": {"type": "GeometryCollection", "geometries": [
+				{"type": "Point", "coordinates": [1, 2, 0]},
+				{"type": "LineString", "coordinates": [[0, 0, 0], [1, 1, 0]]}]}}`, ""},
+		{"numeric id", `{"type": "Feature", "id": 7, "properties": {},
+			"geometry": {"type": "Point", "coordinates": [10, 20, 30]}}`, "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"type": "FeatureCollection", "name": "places", "features": [` + tt.feature + `]}`
+			c, err := ParseGeoJSON([]byte(data), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fc, skipped := ToGeoJSON(c, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+			if len(skipped) > 0 {
+				t.Fatalf("skipped %v", skipped)
+			}
+			if fc.Name != "places" || len(fc.Features) != 1 {
+				t.Fatalf("exported %q with %d features", fc.Name, len(fc.Features))
+			}
+
+			var want, got interface{}
+			if err := json.Unmarshal([]byte(tt.feature), &want); err != nil {
+				t.Fatal(err)
+			}
+			exported, err := json.Marshal(fc.Features[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(exported, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			// numeric ids become packet ids, which are strings
+			if tt.id != "" {
+				want.(map[string]interface{})["id"] = tt.id
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("exported %s, want %s", exported, tt.feature)
+			}
+		})
+	}
+}
+
+func TestFromGeoJSONParts(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		want     []string // the geometry of each child: "point", "polyline" or "polygon"
+	}{
+		{"multi point", `{"type": "MultiPoint", "coordinates": [[1, 2], [3, 4]]}`, []string{"point", "point"}},
+		{"multi line string", `{"type": "MultiLineString", "coordinates": [[[0, 0], [1, 1]], [[2, 2], [3, 3]]]}`,
+			[]string{"polyline", "polyline"}},
+		{"multi polygon", `{"type": "MultiPolygon", "coordinates": [
+			[[[0, 0], [1, 0], [1, 1], [0, 0]]],
+			[[[2, 2], [3, 2], [3, 3], [2, 2]]]]}`, []string{"polygon", "polygon"}},
+		{"geometry collection", `{"type": "GeometryCollection", "geometries": [
+			{"type": "Point", "coordinates": [1, 2]},
+			{"type": "MultiLineString", "coordinates": [[[0, 0], [1, 1]], [[2, 2], [3, 3]]]}]}`,
+			[]string{"point", "polyline", "polyline"}},
+	}
+
+	geometry := func(p Packet) string {
+		switch {
+		case p.Point != nil && p.Position != nil:
+			return "point"
+		case p.Polyline != nil:
+			return "polyline"
+		case p.Polygon != nil:
+			return "polygon"
+		}
+		return ""
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"type": "FeatureCollection", "features": [{"type": "Feature", "id": "m",
+				"properties": {"name": "Parts", "kind": "test"}, "geometry": ` + tt.geometry + `}]}`
+			var styled []string
+			c, err := ParseGeoJSON([]byte(data), func(f Feature, p *Packet) {
+				styled = append(styled, p.Id)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(c.Packets) != len(tt.want)+2 {
+				t.Fatalf("made %d packets, want the document, the feature and %d parts", len(c.Packets), len(tt.want))
+			}
+			parent := c.Packets[1]
+			if parent.Id != "m" || geometry(parent) != "" {
+				t.Errorf("parent is %s", packetJSON(t, parent))
+			}
+			if parent.Properties == nil || (*parent.Properties)["kind"] != "test" {
+				t.Errorf("parent properties are %v", parent.Properties)
+			}
+
+			var ids []string
+			for i, child := range c.Packets[2:] {
+				ids = append(ids, child.Id)
+				if want := "m/" + strconv.Itoa(i); child.Id != want {
+					t.Errorf("child %d has id %q, want %q", i, child.Id, want)
+				}
+				if child.Parent != "m" || child.Name != "Parts" || child.Properties != nil {
+					t.Errorf("child %d is %s", i, packetJSON(t, child))
+				}
+				if g := geometry(child); g != tt.want[i] {
+					t.Errorf("child %d is a %s, want a %s", i, g, tt.want[i])
+				}
+			}
+
+			// the style is called once for each part, and not for the parent
+			if !reflect.DeepEqual(styled, ids) {
+				t.Errorf("styled %v, want %v", styled, ids)
+			}
+		})
+	}
+}
+
+func TestToGeoJSONGathersParts(t *testing.T) {
+	tests := []struct {
+		name     string
+		packets  []string
+		features []string // the id and geometry type of each feature
+		skipped  []string
+	}{
+		{"parts", []string{
+			`{"id": "f", "name": "Stops"}`,
+			`{"id": "a", "parent": "f", "position": {"cartographicDegrees": [1, 2, 0]}}`,
+			`{"id": "b", "parent": "f", "name": "Stops", "position": {"cartographicDegrees": [3, 4, 0]}}`,
+		}, []string{"f MultiPoint"}, nil},
+		{"parts of different types", []string{
+			`{"id": "f"}`,
+			`{"id": "a", "parent": "f", "position": {"cartographicDegrees": [1, 2, 0]}}`,
+			`{"id": "b", "parent": "f", "polyline": {"positions": {"cartographicDegrees": [0, 0, 0, 1, 1, 0]}}}`,
+		}, []string{"f GeometryCollection"}, nil},
+		{"parent with a geometry", []string{
+			`{"id": "f", "position": {"cartographicDegrees": [1, 2, 0]}}`,
+			`{"id": "a", "parent": "f", "position": {"cartographicDegrees": [3, 4, 0]}}`,
+		}, []string{"f GeometryCollection"}, nil},
+		{"children with properties of their own", []string{
+			`{"id": "f", "name": "Stops"}`,
+			`{"id": "a", "parent": "f", "name": "First", "position": {"cartographicDegrees": [1, 2, 0]}}`,
+			`{"id": "b", "parent": "f", "properties": {"kind": "stop"}, "position": {"cartographicDegrees": [3, 4, 0]}}`,
+		}, []string{"a Point", "b Point"}, []string{"f"}},
+		{"children with children", []string{
+			`{"id": "f"}`,
+			`{"id": "a", "parent": "f", "position": {"cartographicDegrees": [1, 2, 0]}}`,
+			`{"id": "b", "parent": "a", "position": {"cartographicDegrees": [3, 4, 0]}}`,
+		}, []string{"a GeometryCollection"}, []string{"f"}},
+		{"unavailable part", []string{
+			`{"id": "f"}`,
+			`{"id": "a", "parent": "f", "position": {"cartographicDegrees": [1, 2, 0]}}`,
+			`{"id": "b", "parent": "f", "availability": "2021-01-01T00:00:00Z/2021-01-02T00:00:00Z", "position": {"cartographicDegrees": [3, 4, 0]}}`,
+		}, []string{"f MultiPoint"}, []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Czml{Packets: append([]Packet{{Id: "document", Version: "1.0"}}, parsePackets(t, tt.packets)...)}
+
+			fc, skipped := ToGeoJSON(c, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+			var features, ids []string
+			for _, f := range fc.Features {
+				features = append(features, f.Id.(string)+" "+f.Geometry.Type)
+			}
+			for _, s := range skipped {
+				ids = append(ids, s.Id)
+			}
+			if !reflect.DeepEqual(features, tt.features) {
+				t.Errorf("features are %v, want %v", features, tt.features)
+			}
+			if !reflect.DeepEqual(ids, tt.skipped) {
+				t.Errorf("skipped %v, want %v", skipped, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestToGeoJSONSkipsPackets(t *testing.T) {
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	deleted := true
+	line := []float64{0, 0, 0, 1, 1, 0}
+
+	tests := []struct {
+		name   string
+		packet Packet
+		want   error
+		reason string
+	}{
+		{"deleting the document", Packet{Id: "document", Delete: &deleted}, nil, "the document packet cannot be deleted"},
+		{"not available", Packet{
+			Id:           "late",
+			Availability: &TimeIntervalCollection{NewTimeInterval(at.Add(time.Hour), at.Add(2*time.Hour))},
+			Polyline:     &Polyline{Positions: NewProperty(PositionList{CartographicDegrees: line})},
+		}, nil, "not available at 2020-01-01T00:00:00Z"},
+		{"inertial list", Packet{
+			Id:       "orbit",
+			Polyline: &Polyline{Positions: NewProperty(PositionList{ReferenceFrame: "INERTIAL", CartographicDegrees: line})},
+		}, nil, "polyline: positions in the INERTIAL reference frame cannot be converted"},
+		{"unsampled time", Packet{
+			Id:       "track",
+			Position: NewProperty(Position{CartographicDegrees: &CartographicDegreesValue{SampledValue{Samples: []Sample{{Time: DateTag(at.Add(time.Hour)), Value: []float64{0, 0, 0}}}}}}),
+		}, ErrNoValue, "position: property has no value at the requested time"},
+		{"no geometry", Packet{Id: "folder"}, ErrNoGeometry, "packet has no geometry GeoJSON can represent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Czml
+			c.InitializeDocument("")
+			c.AddPacket(tt.packet)
+
+			_, skipped := ToGeoJSON(c, at)
+			if len(skipped) != 1 {
+				t.Fatalf("skipped %v, want one packet", skipped)
+			}
+			if skipped[0].Id != tt.packet.Id {
+				t.Errorf("skipped %q, want %q", skipped[0].Id, tt.packet.Id)
+			}
+			if tt.want != nil && !errors.Is(skipped[0], tt.want) {
+				t.Errorf("skipped for %v, want %v", skipped[0].Err, tt.want)
+			}
+
+			msg := skipped[0].Error()
+			if !strings.HasSuffix(msg, ": "+tt.reason) || strings.Count(msg, "czml: ") != 1 {
+				t.Errorf("error is %q, want the reason %q after a single prefix", msg, tt.reason)
+			}
+		})
+	}
+}
+
+func TestGeoJSONPropertyValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string // the GeoJSON property value
+		czml  string // the packet property value
+		want  string // the exported property value
+	}{
+		{"string", `"park"`, `"park"`, `"park"`},
+		{"number", `3`, `3`, `3`},
+		{"null", `null`, `null`, `null`},
+		{"array", `[1,"two",[3]]`, `{"array":[1,"two",[3]]}`, `[1,"two",[3]]`},
+		{"object", `{"a":1,"b":[2]}`, `"{\"a\":1,\"b\":[2]}"`, `"{\"a\":1,\"b\":[2]}"`},
+	}
+
+	written := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"type": "FeatureCollection", "features": [{"type": "Feature", "id": "p",
+				"properties": {"value": ` + tt.value + `}, "geometry": {"type": "Point", "coordinates": [1, 2]}}]}`
+			c, err := ParseGeoJSON([]byte(data), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := written((*c.Packets[1].Properties)["value"]); got != tt.czml {
+				t.Errorf("packet property is %s, want %s", got, tt.czml)
+			}
+
+			fc, skipped := ToGeoJSON(c, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+			if len(skipped) > 0 {
+				t.Fatalf("skipped %v", skipped)
+			}
+			if got := written(fc.Features[0].Properties["value"]); got != tt.want {
+				t.Errorf("exported %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
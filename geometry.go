@@ -0,0 +1,231 @@
+package czml
+
+import (
+	"errors"
+	"math"
+)
+
+// NewCircle returns a packet with a polygon approximating the geodesic circle of a radius in meters
+// around a constant center, using vertices positions
+func NewCircle(id string, center CartographicDegreesValue, radius float64, vertices int, material *Material) (Packet, error) {
+	return NewSector(id, center, radius, 0, 360, vertices, material)
+}
+
+// NewSector returns a packet with a polygon covering the part of a geodesic circle between two
+// azimuths, in degrees clockwise from north, such as the coverage of a radar. The arc has as many
+// positions as that part of a circle of vertices positions, and a sector of 360 degrees or more is
+// the whole circle. Azimuths that are the same, modulo 360 degrees, return an error otherwise.
+func NewSector(id string, center CartographicDegreesValue, radius, start, stop float64, vertices int, material *Material) (Packet, error) {
+	c, err := constantCenter(center, radius, vertices)
+	if err != nil {
+		return Packet{}, err
+	}
+
+	var positions CartographicDegreesListValue
+	if stop-start >= 360 {
+		positions = ring(c, radius, 0, 2*math.Pi, vertices, false)
+	} else {
+		sweep := math.Mod(stop-start, 360)
+		if sweep == 0 {
+			return Packet{}, errors.New("czml: sector has the same start and stop azimuths")
+		}
+		if sweep < 0 {
+			sweep += 360
+		}
+		positions = append(CartographicDegreesListValue{c[0], c[1], c[2]},
+			ring(c, radius, start*math.Pi/180, sweep*math.Pi/180, vertices, true)...)
+	}
+
+	p := Packet{Id: id}
+	p.Polygon = &Polygon{Positions: NewProperty(PositionList{CartographicDegrees: positions}), Material: propertyOf(material)}
+	return p, nil
+}
+
+// NewAnnulus returns a packet with a polygon covering the area between two geodesic circles around
+// a constant center, such as a range ring, with the inner circle as a hole
+func NewAnnulus(id string, center CartographicDegreesValue, inner, outer float64, vertices int, material *Material) (Packet, error) {
+	if inner <= 0 || inner >= outer {
+		return Packet{}, errors.New("czml: inner radius must be greater than zero and less than the outer radius")
+	}
+	p, err := NewCircle(id, center, outer, vertices, material)
+	if err != nil {
+		return Packet{}, err
+	}
+
+	c, _ := constantCenter(center, inner, vertices)
+	hole := ring(c, inner, 0, 2*math.Pi, vertices, false)
+	p.Polygon.Holes = NewProperty(PositionListOfLists{CartographicDegrees: &CartographicDegreesListOfListsValue{hole}})
+	return p, nil
+}
+
+// NewBufferCorridor returns a packet with a corridor of twice a distance in meters wide along a
+// line, with rounded corners. Cesium computes the outline of a corridor itself, and ends it flat
+// at the first and last positions, so unlike NewBufferPolygon it leaves out the points near the
+// ends of the line that are beyond them.
+func NewBufferCorridor(id string, line *PositionList, distance float64, material *Material) (Packet, error) {
+	if distance <= 0 {
+		return Packet{}, errors.New("czml: distance must be greater than zero")
+	}
+	positions, err := line.As(RepresentationCartographicDegrees)
+	if err != nil {
+		return Packet{}, err
+	}
+
+	p := Packet{Id: id}
+	p.Corridor = &Corridor{
+		Positions:  propertyOf(positions),
+		Width:      NewDouble(2 * distance),
+		CornerType: NewProperty(CornerType{CornerType: CornerTypeRounded}),
+		Material:   propertyOf(material),
+	}
+	return p, nil
+}
+
+// NewBufferPolygon returns a packet with a polygon outlining every point within a distance in
+// meters of a line, with rounded ends and corners drawn with the vertices positions of a whole
+// circle. The outline can cross itself on the inside of sharp turns between segments shorter than
+// the distance, where NewBufferCorridor should be used instead.
+func NewBufferPolygon(id string, line *PositionList, distance float64, vertices int, material *Material) (Packet, error) {
+	if distance <= 0 {
+		return Packet{}, errors.New("czml: distance must be greater than zero")
+	}
+	if vertices < 3 {
+		return Packet{}, errors.New("czml: a buffer needs at least 3 vertices")
+	}
+	radians, err := line.As(RepresentationCartographicRadians)
+	if err != nil {
+		return Packet{}, err
+	}
+
+	// repeated positions have no direction, so they are dropped
+	var points [][3]float64
+	list := *radians.CartographicRadians
+	for i := 0; i+2 < len(list); i += 3 {
+		point := [3]float64{list[i], list[i+1], list[i+2]}
+		if len(points) == 0 || point[0] != points[len(points)-1][0] || point[1] != points[len(points)-1][1] {
+			points = append(points, point)
+		}
+	}
+	if len(points) == 0 {
+		return Packet{}, errors.New("czml: line has no positions")
+	}
+
+	var positions CartographicDegreesListValue
+	if len(points) == 1 {
+		c := [3]float64{points[0][0] * 180 / math.Pi, points[0][1] * 180 / math.Pi, points[0][2]}
+		positions = ring(c, distance, 0, 2*math.Pi, vertices, false)
+	} else {
+		reversed := make([][3]float64, len(points))
+		for i, point := range points {
+			reversed[len(points)-1-i] = point
+		}
+
+		left, err := bufferSide(points, distance, vertices)
+		if err != nil {
+			return Packet{}, err
+		}
+		right, err := bufferSide(reversed, distance, vertices)
+		if err != nil {
+			return Packet{}, err
+		}
+		positions = append(left, right...)
+	}
+
+	p := Packet{Id: id}
+	p.Polygon = &Polygon{Positions: NewProperty(PositionList{CartographicDegrees: positions}), Material: propertyOf(material)}
+	return p, nil
+}
+
+// bufferSide returns the outline of a buffer on the left of a line, given in radians, after its
+// first position and up to the cap around its end
+func bufferSide(points [][3]float64, distance float64, vertices int) (CartographicDegreesListValue, error) {
+	// the azimuths of each segment where it leaves its first position and reaches its last
+	leaving := make([]float64, len(points)-1)
+	arriving := make([]float64, len(points)-1)
+	for i := range leaving {
+		a, b := points[i], points[i+1]
+		_, forward, err := vincentyInverse(a[0], a[1], b[0], b[1])
+		if err != nil {
+			return nil, err
+		}
+		_, backward, err := vincentyInverse(b[0], b[1], a[0], a[1])
+		if err != nil {
+			return nil, err
+		}
+		leaving[i], arriving[i] = forward, backward+math.Pi
+	}
+
+	step := 2 * math.Pi / float64(vertices)
+	var outline CartographicDegreesListValue
+	offset := func(point [3]float64, azimuth, d float64) {
+		lon, lat := vincentyDirect(point[0], point[1], azimuth, d)
+		outline = append(outline, lon*180/math.Pi, lat*180/math.Pi, point[2])
+	}
+	arc := func(point [3]float64, from, sweep float64) {
+		n := math.Max(1, math.Ceil(sweep/step))
+		for j := 0.0; j <= n; j++ {
+			offset(point, from+sweep*j/n, distance)
+		}
+	}
+
+	// the outline starts where the cap around the end of the other side finishes
+	for i := 1; i < len(points)-1; i++ {
+		turn := normalizeLongitude(leaving[i] - arriving[i-1])
+		if turn <= -math.Pi+1e-9 {
+			// the line turns back on itself, around the left side
+			turn = math.Pi
+		}
+		if turn >= 0 {
+			// a right turn, with the left side on the outside
+			arc(points[i], arriving[i-1]-math.Pi/2, turn)
+		} else if math.Cos(turn/2) >= 0.5 {
+			// a left turn, where the offset lines meet at the bisector
+			offset(points[i], arriving[i-1]+turn/2-math.Pi/2, distance/math.Cos(turn/2))
+		} else {
+			// a sharp left turn, where the offset lines meet further than twice the distance
+			// away, so their ends are joined instead
+			offset(points[i], arriving[i-1]-math.Pi/2, distance)
+			offset(points[i], leaving[i]-math.Pi/2, distance)
+		}
+	}
+
+	// the cap around the last position, from the left side to the right
+	last := len(points) - 1
+	arc(points[last], arriving[last-1]-math.Pi/2, math.Pi)
+
+	return outline, nil
+}
+
+// constantCenter returns the center of a shape, checking the shape can be built
+func constantCenter(center CartographicDegreesValue, radius float64, vertices int) ([3]float64, error) {
+	if center.IsSampled() || len(center.Value) != 3 {
+		return [3]float64{}, errors.New("czml: center must be a constant position")
+	}
+	if radius <= 0 {
+		return [3]float64{}, errors.New("czml: radius must be greater than zero")
+	}
+	if vertices < 3 {
+		return [3]float64{}, errors.New("czml: a circle needs at least 3 vertices")
+	}
+
+	return [3]float64{center.Value[0], center.Value[1], center.Value[2]}, nil
+}
+
+// ring returns the positions at a geodesic distance from a center given in degrees, between two
+// azimuths in radians, spaced as a circle of vertices positions. The position at the last azimuth
+// is included for arcs, and left out for whole circles where it is the first.
+func ring(center [3]float64, radius, from, sweep float64, vertices int, arc bool) CartographicDegreesListValue {
+	n := float64(vertices)
+	if arc {
+		n = math.Max(1, math.Ceil(sweep/(2*math.Pi)*float64(vertices)))
+	}
+
+	var positions CartographicDegreesListValue
+	lon, lat := center[0]*math.Pi/180, center[1]*math.Pi/180
+	for j := 0.0; j < n || (arc && j == n); j++ {
+		l, b := vincentyDirect(lon, lat, from+sweep*j/n, radius)
+		positions = append(positions, l*180/math.Pi, b*180/math.Pi, center[2])
+	}
+
+	return positions
+}
@@ -0,0 +1,103 @@
+package czml
+
+import (
+	"math"
+	"testing"
+)
+
+// geodesicDistance returns the length of the geodesic between two positions given in degrees
+func geodesicDistance(t *testing.T, lon1, lat1, lon2, lat2 float64) float64 {
+	t.Helper()
+
+	rad := math.Pi / 180
+	d, _, err := vincentyInverse(lon1*rad, lat1*rad, lon2*rad, lat2*rad)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestNewSector(t *testing.T) {
+	tests := []struct {
+		name        string
+		start, stop float64
+		positions   int // including the center, for sectors that are not whole circles
+		wantErr     bool
+	}{
+		{"whole circle", 0, 360, 36, false},
+		{"more than a whole circle", 90, 900, 36, false},
+		{"quarter", 0, 90, 1 + 9 + 1, false},
+		{"across north", 350, 10, 1 + 2 + 1, false},
+		{"stop before start", 90, -180, 1 + 9 + 1, false},
+		{"same azimuths", 45, 45, 0, true},
+		{"whole turn backwards", 90, -270, 0, true},
+	}
+
+	center := CartographicDegreesValue{SampledValue{Value: []float64{10, 50, 0}}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewSector("s", center, 5000, tt.start, tt.stop, 36, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			positions := p.Polygon.Positions.Value.CartographicDegrees
+			if n := len(positions) / 3; n != tt.positions {
+				t.Errorf("sector has %d positions, want %d", n, tt.positions)
+			}
+			for i := 0; i+2 < len(positions); i += 3 {
+				if positions[i] == 10 && positions[i+1] == 50 {
+					continue
+				}
+				if d := geodesicDistance(t, 10, 50, positions[i], positions[i+1]); math.Abs(d-5000) > 1e-6 {
+					t.Errorf("position %d is %.9f m from the center, want 5000 m", i/3, d)
+				}
+			}
+		})
+	}
+}
+
+func TestNewBufferPolygonMiters(t *testing.T) {
+	tests := []struct {
+		name string
+		line []float64
+	}{
+		{"straight", []float64{0, 0, 0, 0.01, 0, 0, 0.02, 0, 0}},
+		{"right turn", []float64{0, 0, 0, 0.01, 0, 0, 0.01, -0.01, 0}},
+		{"left turn", []float64{0, 0, 0, 0.01, 0, 0, 0.01, 0.01, 0}},
+		{"sharp left turn", []float64{0, 0, 0, 0.01, 0, 0, 0, 0.002, 0}},
+		{"nearly reversing left turn", []float64{0, 0, 0, 0.01, 0, 0, 0, -0.00001, 0}},
+		{"nearly reversing right turn", []float64{0, 0, 0, 0.01, 0, 0, 0, 0.00001, 0}},
+		{"reversing", []float64{0, 0, 0, 0.01, 0, 0, 0, 0, 0}},
+		{"zigzag", []float64{0, 0, 0, 0.01, 0, 0, 0.0001, 0.0001, 0, 0.01, 0.0002, 0}},
+	}
+
+	const distance = 100
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewBufferPolygon("b", &PositionList{CartographicDegrees: tt.line}, distance, 16, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// every vertex is offset from a position of the line, at most by twice the distance
+			outline := p.Polygon.Positions.Value.CartographicDegrees
+			for i := 0; i+2 < len(outline); i += 3 {
+				nearest := math.Inf(1)
+				for j := 0; j+2 < len(tt.line); j += 3 {
+					nearest = math.Min(nearest, geodesicDistance(t, tt.line[j], tt.line[j+1], outline[i], outline[i+1]))
+				}
+				if nearest > 2*distance+1e-6 {
+					t.Errorf("vertex %d at %.6f, %.6f is %.1f m from the line", i/3, outline[i], outline[i+1], nearest)
+				}
+			}
+		})
+	}
+}
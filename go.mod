@@ -1,5 +1,5 @@
 module github.com/cconcannon/czml
 
-go 1.16
+go 1.18
 
 retract v0.0.7
\ No newline at end of file
@@ -0,0 +1,221 @@
+package czml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Hierarchy is a view of the parent and child relationships set by the Parent property of the
+// packets of a Czml. When several packets share an id, the last parent set applies.
+type Hierarchy struct {
+	ids      []string
+	exists   map[string]bool
+	parents  map[string]string
+	children map[string][]string
+}
+
+// NewFolder returns a packet that holds only a name, used to group entities in Cesium's entity
+// tree by setting it as their parent
+func NewFolder(id, name string) Packet {
+	return CreateEmptyPacket(id, name)
+}
+
+// Hierarchy returns the parent and child relationships between the entities of c. The document
+// packet and packets without an id are not part of it.
+func (c *Czml) Hierarchy() *Hierarchy {
+	h := &Hierarchy{exists: map[string]bool{}, parents: map[string]string{}, children: map[string][]string{}}
+
+	for _, p := range c.Packets {
+		if p.Id == "" || p.Id == "document" {
+			continue
+		}
+		if !h.exists[p.Id] {
+			h.exists[p.Id] = true
+			h.ids = append(h.ids, p.Id)
+		}
+		if p.Parent != "" {
+			h.parents[p.Id] = p.Parent
+		}
+	}
+
+	for _, id := range h.ids {
+		if parent, ok := h.parents[id]; ok {
+			h.children[parent] = append(h.children[parent], id)
+		}
+	}
+
+	return h
+}
+
+// Contains reports whether the hierarchy holds an entity
+func (h *Hierarchy) Contains(id string) bool {
+	return h.exists[id]
+}
+
+// Parent returns the parent of an entity, or false if it has none
+func (h *Hierarchy) Parent(id string) (string, bool) {
+	parent, ok := h.parents[id]
+	return parent, ok
+}
+
+// Children returns the entities whose parent is id, in the order they first appear
+func (h *Hierarchy) Children(id string) []string {
+	return append([]string(nil), h.children[id]...)
+}
+
+// Roots returns the entities without a parent, in the order they first appear
+func (h *Hierarchy) Roots() []string {
+	var roots []string
+	for _, id := range h.ids {
+		if _, ok := h.parents[id]; !ok {
+			roots = append(roots, id)
+		}
+	}
+
+	return roots
+}
+
+// Ancestors returns the parent of an entity, its parent, and so on up to an entity without a
+// parent or whose parent does not exist. It returns an error if the chain of parents is a cycle.
+func (h *Hierarchy) Ancestors(id string) ([]string, error) {
+	var ancestors []string
+	visited := map[string]bool{id: true}
+	for current := id; ; {
+		parent, ok := h.parents[current]
+		if !ok || !h.Contains(parent) {
+			return ancestors, nil
+		}
+		if visited[parent] {
+			return ancestors, fmt.Errorf("czml: parents of %q form a cycle", id)
+		}
+
+		visited[parent] = true
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+}
+
+// Descendants returns the children of an entity, their children, and so on, depth first
+func (h *Hierarchy) Descendants(id string) []string {
+	var descendants []string
+	visited := map[string]bool{id: true}
+
+	var visit func(id string)
+	visit = func(id string) {
+		for _, child := range h.children[id] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			descendants = append(descendants, child)
+			visit(child)
+		}
+	}
+	visit(id)
+
+	return descendants
+}
+
+// Cycles returns every chain of parents that loops back on itself, including entities that are
+// their own parent. Each cycle is listed once, starting from the entity that appears first.
+func (h *Hierarchy) Cycles() [][]string {
+	var cycles [][]string
+	done := map[string]bool{}
+
+	for _, start := range h.ids {
+		var chain []string
+		position := map[string]int{}
+		for id := start; !done[id]; {
+			position[id] = len(chain)
+			chain = append(chain, id)
+			parent, ok := h.parents[id]
+			if !ok || !h.Contains(parent) {
+				break
+			}
+			if i, ok := position[parent]; ok {
+				cycles = append(cycles, append([]string(nil), chain[i:]...))
+				break
+			}
+			id = parent
+		}
+
+		for _, id := range chain {
+			done[id] = true
+		}
+	}
+
+	return cycles
+}
+
+// Dangling returns the entities whose parent does not exist
+func (h *Hierarchy) Dangling() []string {
+	var dangling []string
+	for _, id := range h.ids {
+		if parent, ok := h.parents[id]; ok && !h.Contains(parent) {
+			dangling = append(dangling, id)
+		}
+	}
+
+	return dangling
+}
+
+// Reparent sets the parent of every packet of an entity. An empty parent moves the entity to the
+// root. It returns an error if either entity does not exist, or if the move would create a cycle.
+func (c *Czml) Reparent(id, parent string) error {
+	h := c.Hierarchy()
+	if !h.Contains(id) {
+		return fmt.Errorf("czml: entity %q does not exist", id)
+	}
+	if parent != "" {
+		if !h.Contains(parent) {
+			return fmt.Errorf("czml: parent %q does not exist", parent)
+		}
+		if parent == id {
+			return errors.New("czml: an entity cannot be its own parent")
+		}
+		for _, d := range h.Descendants(id) {
+			if d == parent {
+				return fmt.Errorf("czml: %q is a descendant of %q", parent, id)
+			}
+		}
+	}
+
+	for i := range c.Packets {
+		if c.Packets[i].Id == id {
+			c.Packets[i].Parent = parent
+		}
+	}
+
+	return nil
+}
+
+// RemoveSubtree removes every packet of an entity and of its descendants, and returns the ids
+// removed
+func (c *Czml) RemoveSubtree(id string) ([]string, error) {
+	h := c.Hierarchy()
+	if !h.Contains(id) {
+		return nil, fmt.Errorf("czml: entity %q does not exist", id)
+	}
+
+	removed := append([]string{id}, h.Descendants(id)...)
+	remove := map[string]bool{}
+	for _, r := range removed {
+		remove[r] = true
+	}
+
+	kept := make([]Packet, 0, len(c.Packets))
+	for _, p := range c.Packets {
+		if !remove[p.Id] {
+			kept = append(kept, p)
+		}
+	}
+	c.Packets = kept
+
+	return removed, nil
+}
+
+// cycleString formats a cycle of parents as "a -> b -> a"
+func cycleString(cycle []string) string {
+	return strings.Join(cycle, " -> ") + " -> " + cycle[0]
+}
@@ -0,0 +1,143 @@
+package czml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// family returns a document of entities given as id and parent pairs, after the document packet
+func family(pairs ...string) Czml {
+	var c Czml
+	c.InitializeDocument("family")
+	for i := 0; i < len(pairs); i += 2 {
+		c.Packets = append(c.Packets, Packet{Id: pairs[i], Parent: pairs[i+1]})
+	}
+
+	return c
+}
+
+// parents returns the parent of every packet after the document packet, in order
+func parents(c Czml) []string {
+	var result []string
+	for _, p := range c.Packets[1:] {
+		result = append(result, p.Id+"<"+p.Parent)
+	}
+
+	return result
+}
+
+func TestReparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      Czml
+		id     string
+		parent string
+		want   []string // the parent of every packet, or nil for an error
+		err    string
+	}{
+		{"move", family("a", "", "b", "", "c", "b"), "c", "a", []string{"a<", "b<", "c<a"}, ""},
+		{"every packet of the entity", family("a", "", "b", "", "c", "", "c", "b"), "c", "a", []string{"a<", "b<", "c<a", "c<a"}, ""},
+		{"to the root", family("a", "", "b", "a"), "b", "", []string{"a<", "b<"}, ""},
+		{"under a sibling", family("a", "", "b", "a", "c", "a"), "c", "b", []string{"a<", "b<a", "c<b"}, ""},
+		{"missing entity", family("a", ""), "b", "a", nil, `entity "b" does not exist`},
+		{"missing parent", family("a", ""), "a", "b", nil, `parent "b" does not exist`},
+		{"own parent", family("a", ""), "a", "a", nil, "cannot be its own parent"},
+		{"under a child", family("a", "", "b", "a"), "a", "b", nil, `"b" is a descendant of "a"`},
+		{"under a grandchild", family("a", "", "b", "a", "c", "b"), "a", "c", nil, `"c" is a descendant of "a"`},
+		{"within a cycle", family("a", "b", "b", "a", "c", ""), "a", "c", []string{"a<c", "b<a", "c<"}, ""},
+		{"into a cycle", family("a", "b", "b", "a", "c", ""), "c", "a", []string{"a<b", "b<a", "c<a"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := parents(tt.c)
+			err := tt.c.Reparent(tt.id, tt.parent)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error is %v, want %q", err, tt.err)
+				}
+				if got := parents(tt.c); !reflect.DeepEqual(got, before) {
+					t.Errorf("parents changed to %v after an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := parents(tt.c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parents are %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveSubtree(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Czml
+		id      string
+		removed []string
+		kept    []string
+	}{
+		{"leaf", family("a", "", "b", "a"), "b", []string{"b"}, []string{"a<"}},
+		{"descendants", family("a", "", "b", "a", "c", "b", "d", ""), "a", []string{"a", "b", "c"}, []string{"d<"}},
+		{"every packet of the entity", family("a", "", "b", "", "b", "a"), "b", []string{"b"}, []string{"a<"}},
+		{"siblings are kept", family("a", "", "b", "a", "c", "a"), "b", []string{"b"}, []string{"a<", "c<a"}},
+		{"cycle", family("a", "b", "b", "a", "c", ""), "a", []string{"a", "b"}, []string{"c<"}},
+		{"child of a cycle", family("a", "b", "b", "a", "c", "a"), "c", []string{"c"}, []string{"a<b", "b<a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.c.Packets
+			before := parents(tt.c)
+
+			removed, err := tt.c.RemoveSubtree(tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(removed, tt.removed) {
+				t.Errorf("removed %v, want %v", removed, tt.removed)
+			}
+			if got := parents(tt.c); !reflect.DeepEqual(got, tt.kept) {
+				t.Errorf("kept %v, want %v", got, tt.kept)
+			}
+			if tt.c.Packets[0].Id != "document" {
+				t.Error("the document packet was removed")
+			}
+			// a copy of the document made before is left as it was
+			if got := parents(Czml{Packets: original}); !reflect.DeepEqual(got, before) {
+				t.Errorf("the original packets changed to %v", got)
+			}
+		})
+	}
+
+	c := family("a", "")
+	if _, err := c.RemoveSubtree("b"); err == nil {
+		t.Error("removed an entity that does not exist")
+	}
+}
+
+func TestHierarchyCycles(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Czml
+		want [][]string
+	}{
+		{"none", family("a", "", "b", "a"), nil},
+		{"own parent", family("a", "a"), [][]string{{"a"}}},
+		{"pair", family("a", "b", "b", "a", "c", "a"), [][]string{{"a", "b"}}},
+		{"reached from outside", family("c", "a", "a", "b", "b", "a"), [][]string{{"a", "b"}}},
+		{"two cycles", family("a", "b", "b", "a", "c", "d", "d", "c"), [][]string{{"a", "b"}, {"c", "d"}}},
+		{"dangling parent", family("a", "missing"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Hierarchy().Cycles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cycles are %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
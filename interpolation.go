@@ -0,0 +1,58 @@
+package czml
+
+// InterpolationAlgorithm is the algorithm used to interpolate between the samples of a property.
+// Valid values are `LINEAR`, `LAGRANGE`, and `HERMITE`
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/InterpolationAlgorithmValue
+type InterpolationAlgorithm string
+
+const (
+	InterpolationLinear   InterpolationAlgorithm = "LINEAR"
+	InterpolationLagrange InterpolationAlgorithm = "LAGRANGE"
+	InterpolationHermite  InterpolationAlgorithm = "HERMITE"
+)
+
+// ExtrapolationType is the type of extrapolation to perform when a value is requested at a time
+// before or after the available samples.
+// Valid values are `NONE`, `HOLD`, and `EXTRAPOLATE`
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ExtrapolationTypeValue
+type ExtrapolationType string
+
+const (
+	ExtrapolationNone        ExtrapolationType = "NONE"
+	ExtrapolationHold        ExtrapolationType = "HOLD"
+	ExtrapolationExtrapolate ExtrapolationType = "EXTRAPOLATE"
+)
+
+// Interpolatable holds the properties shared by every property that can be sampled over time. It
+// is embedded in those properties, so its fields are written alongside their values. Epoch is the
+// time that samples given in seconds are relative to.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/InterpolatableProperty
+type Interpolatable struct {
+	Epoch                         *JulianDate            `json:"epoch,omitempty"`
+	InterpolationAlgorithm        InterpolationAlgorithm `json:"interpolationAlgorithm,omitempty"`
+	InterpolationDegree           *int                   `json:"interpolationDegree,omitempty"`
+	ForwardExtrapolationType      ExtrapolationType      `json:"forwardExtrapolationType,omitempty"`
+	ForwardExtrapolationDuration  *float64               `json:"forwardExtrapolationDuration,omitempty"`
+	BackwardExtrapolationType     ExtrapolationType      `json:"backwardExtrapolationType,omitempty"`
+	BackwardExtrapolationDuration *float64               `json:"backwardExtrapolationDuration,omitempty"`
+}
+
+// SetInterpolation sets the algorithm and degree used to interpolate between samples
+func (i *Interpolatable) SetInterpolation(algorithm InterpolationAlgorithm, degree int) {
+	i.InterpolationAlgorithm = algorithm
+	i.InterpolationDegree = &degree
+}
+
+// SetForwardExtrapolation sets how values are found after the last sample. A zero duration
+// extrapolates indefinitely.
+func (i *Interpolatable) SetForwardExtrapolation(t ExtrapolationType, duration float64) {
+	i.ForwardExtrapolationType = t
+	i.ForwardExtrapolationDuration = &duration
+}
+
+// SetBackwardExtrapolation sets how values are found before the first sample. A zero duration
+// extrapolates indefinitely.
+func (i *Interpolatable) SetBackwardExtrapolation(t ExtrapolationType, duration float64) {
+	i.BackwardExtrapolationType = t
+	i.BackwardExtrapolationDuration = &duration
+}
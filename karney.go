@@ -0,0 +1,567 @@
+package czml
+
+import "math"
+
+// Karney's solution of the inverse geodesic problem, from "Algorithms for geodesics", J. Geod. 87
+// (2013), following GeographicLib with series to the sixth order in the third flattening. It is
+// used for nearly antipodal positions, where Vincenty's inverse formula fails.
+
+const (
+	karneyOrder     = 6
+	karneyMaxNewton = 20
+	karneyMaxIter   = karneyMaxNewton + 53 + 10
+)
+
+var (
+	karneyN    = wgs84F / (2 - wgs84F)
+	karneyEp2  = wgs84E2 / ((1 - wgs84F) * (1 - wgs84F))
+	karneyTiny = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52))
+	karneyTol0 = math.Nextafter(1, 2) - 1
+	karneyTol1 = 200 * karneyTol0
+	karneyTol2 = math.Sqrt(karneyTol0)
+	karneyTolb = karneyTol0 * karneyTol2
+	karneyEtol = 0.1 * karneyTol2 / math.Sqrt(math.Max(0.001, wgs84F)*math.Min(1, 1-wgs84F/2)/2)
+	karneyA3x  = karneyA3Coefficients()
+	karneyC3x  = karneyC3Coefficients()
+)
+
+// karneyInverse returns the length of the geodesic between two positions and its azimuth at the
+// first, in the same form as vincentyInverse
+func karneyInverse(lon1, lat1, lon2, lat2 float64) (float64, float64, error) {
+	const degree = math.Pi / 180
+
+	// longitudes and latitudes are handled in degrees, so that angles such as 180° have exact
+	// sines and cosines
+	lon12 := normalizeLongitude(lon2-lon1) / degree
+	lonSign := 1.0
+	if lon12 < 0 {
+		lonSign = -1
+	}
+	lon12 *= lonSign
+	lon12s := 180 - lon12
+	lam12 := lon12 * degree
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	// the first position has the larger latitude, which is negative
+	phi1, phi2 := lat1/degree, lat2/degree
+	swap := 1.0
+	if math.Abs(phi1) < math.Abs(phi2) {
+		swap = -1
+		lonSign = -lonSign
+		phi1, phi2 = phi2, phi1
+	}
+	latSign := -1.0
+	if phi1 < 0 {
+		latSign = 1
+	}
+	phi1 *= latSign
+	phi2 *= latSign
+
+	sbet1, cbet1 := reducedLatitude(phi1)
+	sbet2, cbet2 := reducedLatitude(phi2)
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			if sbet2 < 0 {
+				sbet2 = sbet1
+			} else {
+				sbet2 = -sbet1
+			}
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+	dn1 := math.Sqrt(1 + karneyEp2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + karneyEp2*sbet2*sbet2)
+
+	var salp1, calp1, salp2, calp2, s12 float64
+	meridian := phi1 == -90 || slam12 == 0
+	if meridian {
+		// the geodesic runs along a meridian, possibly over a pole
+		salp1, calp1 = slam12, clam12
+		salp2, calp2 = 0, 1
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12b, m12b := karneyLengths(karneyN, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+		if sig12 < 1 || m12b >= 0 {
+			if sig12 < 3*karneyTiny || (sig12 < karneyTol0 && (s12b < 0 || m12b < 0)) {
+				s12b = 0
+			}
+			s12 = wgs84B * s12b
+		} else {
+			// the meridian is not the shortest geodesic
+			meridian = false
+		}
+	}
+
+	switch {
+	case meridian:
+	case sbet1 == 0 && lon12s >= wgs84F*180:
+		// the geodesic runs along the equator
+		salp1, calp1, salp2, calp2 = 1, 0, 1, 0
+		s12 = wgs84A * lam12
+	default:
+		start := karneyInverseStart(sbet1, cbet1, sbet2, cbet2, lam12, slam12, clam12)
+		salp1, calp1 = start.salp1, start.calp1
+		if start.sig12 >= 0 {
+			// a short line, solved on an auxiliary sphere
+			salp2, calp2 = start.salp2, start.calp2
+			s12 = start.sig12 * wgs84B * start.dnm
+			break
+		}
+
+		// Newton's method on the azimuth at the first position, with bisection when it fails
+		salp1a, calp1a, salp1b, calp1b := karneyTiny, 1.0, karneyTiny, -1.0
+		tripn, tripb := false, false
+		var l karneyLambda
+		for i := 0; ; i++ {
+			l = karneyLambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12, i < karneyMaxNewton)
+			v := l.lam12
+			tolerance := karneyTol0
+			if tripn {
+				tolerance *= 8
+			}
+			if tripb || !(math.Abs(v) >= tolerance) || i == karneyMaxIter {
+				break
+			}
+
+			if v > 0 && (i < karneyMaxNewton || calp1/salp1 > calp1b/salp1b) {
+				salp1b, calp1b = salp1, calp1
+			} else if v < 0 && (i < karneyMaxNewton || calp1/salp1 < calp1a/salp1a) {
+				salp1a, calp1a = salp1, calp1
+			}
+
+			if i < karneyMaxNewton && l.dlam12 > 0 {
+				dalp1 := -v / l.dlam12
+				if math.Abs(dalp1) < math.Pi {
+					sdalp1, cdalp1 := math.Sincos(dalp1)
+					if nsalp1 := salp1*cdalp1 + calp1*sdalp1; nsalp1 > 0 {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1 = nsalp1
+						salp1, calp1 = normalize(salp1, calp1)
+						tripn = math.Abs(v) <= 16*karneyTol0
+						continue
+					}
+				}
+			}
+
+			salp1, calp1 = normalize((salp1a+salp1b)/2, (calp1a+calp1b)/2)
+			tripn = false
+			tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < karneyTolb ||
+				math.Abs(salp1-salp1b)+(calp1-calp1b) < karneyTolb
+		}
+
+		salp2, calp2 = l.salp2, l.calp2
+		s12b, _ := karneyLengths(l.eps, l.sig12, l.ssig1, l.csig1, dn1, l.ssig2, l.csig2, dn2)
+		s12 = wgs84B * s12b
+	}
+
+	if swap < 0 {
+		// the azimuth at the first position is the reverse of the one found at the second
+		salp1, calp1 = salp2, calp2
+	}
+	salp1 *= swap * lonSign
+	calp1 *= swap * latSign
+
+	return s12, math.Atan2(salp1, calp1), nil
+}
+
+// karneyStart is the first estimate of the azimuth at the first position, or the solution for
+// short lines when sig12 is not negative
+type karneyStart struct {
+	sig12, salp1, calp1, salp2, calp2, dnm float64
+}
+
+func karneyInverseStart(sbet1, cbet1, sbet2, cbet2, lam12, slam12, clam12 float64) karneyStart {
+	f1 := 1 - wgs84F
+	s := karneyStart{sig12: -1}
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+
+	shortLine := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	somg12, comg12 := slam12, clam12
+	if shortLine {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		s.dnm = math.Sqrt(1 + karneyEp2*sbetm2)
+		somg12, comg12 = math.Sincos(lam12 / (f1 * s.dnm))
+	}
+
+	s.salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		s.calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		s.calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(s.salp1, s.calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+	switch {
+	case shortLine && ssig12 < karneyEtol:
+		s.salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			s.calp2 = sbet12 - cbet1*sbet2*somg12*somg12/(1+comg12)
+		} else {
+			s.calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		s.salp2, s.calp2 = normalize(s.salp2, s.calp2)
+		s.sig12 = math.Atan2(ssig12, csig12)
+	case csig12 >= 0 || ssig12 >= 6*karneyN*math.Pi*cbet1*cbet1:
+		// the spherical estimate is good enough
+	default:
+		// nearly antipodal positions, estimated from the solution of the astroid problem in
+		// coordinates where the antipode is at the origin
+		lam12x := math.Atan2(-slam12, -clam12)
+		k2 := sbet1 * sbet1 * karneyEp2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamScale := wgs84F * cbet1 * karneyA3(eps) * math.Pi
+		betScale := lamScale * cbet1
+		x := lam12x / lamScale
+		y := sbet12a / betScale
+
+		if y > -karneyTol1 && x > -1-1000*karneyTol2 {
+			s.salp1 = math.Min(1, -x)
+			s.calp1 = -math.Sqrt(1 - s.salp1*s.salp1)
+		} else {
+			k := astroid(x, y)
+			somg12, comg12 = math.Sincos(lamScale * -x * k / (1 + k))
+			comg12 = -comg12
+			s.salp1 = cbet2 * somg12
+			s.calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+
+	if !(s.salp1 <= 0) {
+		s.salp1, s.calp1 = normalize(s.salp1, s.calp1)
+	} else {
+		s.salp1, s.calp1 = 1, 0
+	}
+
+	return s
+}
+
+// karneyLambda is the geodesic leaving the first position at an azimuth, with the difference
+// between its longitude at the latitude of the second position and the longitude of the second
+type karneyLambda struct {
+	lam12, dlam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps float64
+}
+
+func karneyLambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, derivative bool) karneyLambda {
+	f1 := 1 - wgs84F
+	var l karneyLambda
+	if sbet1 == 0 && calp1 == 0 {
+		calp1 = -karneyTiny
+	}
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	somg1 := salp0 * sbet1
+	comg1 := calp1 * cbet1
+	l.ssig1, l.csig1 = normalize(sbet1, comg1)
+
+	l.salp2 = salp1
+	if cbet2 != cbet1 {
+		l.salp2 = salp0 / cbet2
+	}
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var d float64
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			d = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		l.calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+d) / cbet2
+	} else {
+		l.calp2 = math.Abs(calp1)
+	}
+	somg2 := salp0 * sbet2
+	comg2 := l.calp2 * cbet2
+	l.ssig2, l.csig2 = normalize(sbet2, comg2)
+
+	l.sig12 = math.Atan2(math.Max(0, l.csig1*l.ssig2-l.ssig1*l.csig2), l.csig1*l.csig2+l.ssig1*l.ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * karneyEp2
+	l.eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	c3 := karneyC3(l.eps)
+	b312 := sinCosSeries(true, l.ssig2, l.csig2, c3) - sinCosSeries(true, l.ssig1, l.csig1, c3)
+	l.lam12 = eta - wgs84F*karneyA3(l.eps)*salp0*(l.sig12+b312)
+
+	if derivative {
+		if l.calp2 == 0 {
+			l.dlam12 = -2 * f1 * dn1 / sbet1
+		} else {
+			_, m12b := karneyLengths(l.eps, l.sig12, l.ssig1, l.csig1, dn1, l.ssig2, l.csig2, dn2)
+			l.dlam12 = m12b * f1 / (l.calp2 * cbet2)
+		}
+	}
+
+	return l
+}
+
+// karneyLengths returns the length and the reduced length of a geodesic, divided by the semi-minor
+// axis
+func karneyLengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64) (float64, float64) {
+	a1 := a1m1(eps)
+	a2 := a2m1(eps)
+	m0 := a1 - a2
+	a1, a2 = 1+a1, 1+a2
+
+	c1, c2 := c1Coefficients(eps), c2Coefficients(eps)
+	b1 := sinCosSeries(true, ssig2, csig2, c1) - sinCosSeries(true, ssig1, csig1, c1)
+	b2 := sinCosSeries(true, ssig2, csig2, c2) - sinCosSeries(true, ssig1, csig1, c2)
+	j12 := m0*sig12 + (a1*b1 - a2*b2)
+
+	s12b := a1 * (sig12 + b1)
+	m12b := dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+
+	return s12b, m12b
+}
+
+// astroid solves k⁴ + 2k³ − (x² + y² − 1)k² − 2y²k − y² = 0 for its positive root
+func astroid(x, y float64) float64 {
+	p, q := x*x, y*y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		u += 2 * r * math.Cos(math.Atan2(math.Sqrt(-disc), -(s+r3))/3)
+	}
+
+	v := math.Sqrt(u*u + q)
+	uv := u + v
+	if u < 0 {
+		uv = q / (v - u)
+	}
+	w := (uv - q) / (2 * v)
+
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// sinCosSeries returns the sum of c[l] sin(2lx), or of c[l] cos((2l+1)x) when sinp is false, using
+// Clenshaw summation. c[0] is not used for the sine series.
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+	k := len(c)
+	n := k
+	if sinp {
+		n--
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	if n&1 == 1 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		y1 = ar*y0 - y1 + c[k-1]
+		y0 = ar*y1 - y0 + c[k-2]
+		k -= 2
+	}
+
+	if sinp {
+		return 2 * sinx * cosx * y0
+	}
+	return cosx * (y0 - y1)
+}
+
+// polyval evaluates the polynomial of degree n with coefficients p, highest first
+func polyval(n int, p []float64, x float64) float64 {
+	if n < 0 {
+		return 0
+	}
+	y := p[0]
+	for _, c := range p[1 : n+1] {
+		y = y*x + c
+	}
+
+	return y
+}
+
+// series returns the coefficients of a series in eps, from groups of coefficients of polynomials in
+// x, each followed by its divisor. order returns the degree of the polynomial of each term.
+func series(coefficients []float64, terms int, order func(i int) int, x float64) []float64 {
+	result := make([]float64, terms)
+	o := 0
+	for i := range result {
+		p := order(i)
+		result[i] = polyval(p, coefficients[o:], x) / coefficients[o+p+1]
+		o += p + 2
+	}
+
+	return result
+}
+
+// a1m1 returns A1 − 1
+func a1m1(eps float64) float64 {
+	t := polyval(3, []float64{1, 4, 64, 0}, eps*eps) / 256
+	return (t + eps) / (1 - eps)
+}
+
+// a2m1 returns A2 − 1
+func a2m1(eps float64) float64 {
+	t := polyval(3, []float64{-11, -28, -192, 0}, eps*eps) / 256
+	return (t - eps) / (1 + eps)
+}
+
+// c1Coefficients returns C1[l], with C1[0] unused
+func c1Coefficients(eps float64) []float64 {
+	return epsSeries([]float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}, eps)
+}
+
+// c2Coefficients returns C2[l], with C2[0] unused
+func c2Coefficients(eps float64) []float64 {
+	return epsSeries([]float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}, eps)
+}
+
+// epsSeries returns the terms of a series whose lth term is eps^l times a polynomial in eps²
+func epsSeries(coefficients []float64, eps float64) []float64 {
+	terms := series(coefficients, karneyOrder, func(i int) int { return (karneyOrder - i - 1) / 2 }, eps*eps)
+	c := make([]float64, karneyOrder+1)
+	d := eps
+	for l := 1; l <= karneyOrder; l++ {
+		c[l] = d * terms[l-1]
+		d *= eps
+	}
+
+	return c
+}
+
+// karneyA3Coefficients returns the coefficients of A3 as a polynomial in eps, highest first
+func karneyA3Coefficients() []float64 {
+	return series([]float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}, karneyOrder, func(i int) int { return seriesOrder(karneyOrder - 1 - i) }, karneyN)
+}
+
+// karneyC3Coefficients returns the coefficients of C3[l] as polynomials in eps, highest first
+func karneyC3Coefficients() []float64 {
+	var orders []int
+	for l := 1; l < karneyOrder; l++ {
+		for j := karneyOrder - 1; j >= l; j-- {
+			orders = append(orders, seriesOrder(j))
+		}
+	}
+
+	return series([]float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}, len(orders), func(i int) int { return orders[i] }, karneyN)
+}
+
+// seriesOrder returns the degree in n of the coefficient of eps^j in A3 and C3
+func seriesOrder(j int) int {
+	if j < karneyOrder-j-1 {
+		return j
+	}
+	return karneyOrder - j - 1
+}
+
+func karneyA3(eps float64) float64 {
+	return polyval(karneyOrder-1, karneyA3x, eps)
+}
+
+// karneyC3 returns C3[l], with C3[0] unused
+func karneyC3(eps float64) []float64 {
+	c := make([]float64, karneyOrder)
+	mult, o := 1.0, 0
+	for l := 1; l < karneyOrder; l++ {
+		p := karneyOrder - l - 1
+		mult *= eps
+		c[l] = mult * polyval(p, karneyC3x[o:], eps)
+		o += p + 1
+	}
+
+	return c
+}
+
+// reducedLatitude returns the sine and cosine of the reduced latitude of a latitude in degrees
+func reducedLatitude(lat float64) (float64, float64) {
+	s, c := sincosd(lat)
+	s, c = normalize((1-wgs84F)*s, c)
+
+	return s, math.Max(karneyTiny, c)
+}
+
+// sincosd returns the sine and cosine of an angle in degrees, exact for multiples of 90°
+func sincosd(x float64) (float64, float64) {
+	r := math.Mod(x, 360)
+	q := math.Round(r / 90)
+	r -= 90 * q
+	s, c := math.Sincos(r * math.Pi / 180)
+
+	switch int(q) & 3 {
+	case 0:
+		return s, c
+	case 1:
+		return c, -s
+	case 2:
+		return -s, -c
+	}
+	return -c, s
+}
+
+// normalize returns a sine and cosine scaled so that their squares add up to one
+func normalize(s, c float64) (float64, float64) {
+	h := math.Hypot(s, c)
+	return s / h, c / h
+}
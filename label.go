@@ -3,33 +3,34 @@ package czml
 // Label is a string of text
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Label
 type Label struct {
-	Show                       *bool                     `json:"show,omitempty"`
-	Text                       string                    `json:"text,omitempty"`
-	Font                       *Font                     `json:"font,omitempty"`
-	Style                      *LabelStyle               `json:"style,omitempty"`
-	Scale                      *float64                  `json:"scale,omitempty"`
-	ShowBackground             *bool                     `json:"showBackground,omitempty"`
-	BackgroundColor            *Color                    `json:"backgroundColor,omitempty"`
-	BackgroundPadding          *BackgroundPadding        `json:"backgroundPadding,omitempty"`
-	PixelOffset                *PixelOffset              `json:"pixelOffset,omitempty"`
-	EyeOffset                  *EyeOffset                `json:"eyeOffset,omitempty"`
-	HorizontalOrigin           *HorizontalOrigin         `json:"horizontalOrigin,omitempty"`
-	VerticalOrigin             *VerticalOrigin           `json:"verticalOrigin,omitempty"`
-	HeightReference            *HeightReference          `json:"heightReference,omitempty"`
-	FillColor                  *Color                    `json:"fillColor,omitempty"`
-	OutlineColor               *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth               *float64                  `json:"outlineWidth,omitempty"`
-	TranslucencyByDistance     *NearFarScaler            `json:"translucencyByDistance,omitempty"`
-	PixelOffsetScaleByDistance *NearFarScaler            `json:"pixelOffsetScaleByDistance,omitempty"`
-	ScaleByDistance            *NearFarScaler            `json:"scaleByDistance,omitempty"`
-	DistanceDisplayCondition   *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	DisableDepthTestDistance   *float64                  `json:"disableDepthTestDistance,omitempty"`
+	Show                       *Property[bool]                     `json:"show,omitempty"`
+	Text                       *Property[string]                   `json:"text,omitempty"`
+	Font                       *Property[Font]                     `json:"font,omitempty"`
+	Style                      *Property[LabelStyle]               `json:"style,omitempty"`
+	Scale                      *Property[Double]                   `json:"scale,omitempty"`
+	ShowBackground             *Property[bool]                     `json:"showBackground,omitempty"`
+	BackgroundColor            *Property[Color]                    `json:"backgroundColor,omitempty"`
+	BackgroundPadding          *Property[BackgroundPadding]        `json:"backgroundPadding,omitempty"`
+	PixelOffset                *Property[PixelOffset]              `json:"pixelOffset,omitempty"`
+	EyeOffset                  *Property[EyeOffset]                `json:"eyeOffset,omitempty"`
+	HorizontalOrigin           *Property[HorizontalOrigin]         `json:"horizontalOrigin,omitempty"`
+	VerticalOrigin             *Property[VerticalOrigin]           `json:"verticalOrigin,omitempty"`
+	HeightReference            *Property[HeightReference]          `json:"heightReference,omitempty"`
+	FillColor                  *Property[Color]                    `json:"fillColor,omitempty"`
+	OutlineColor               *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth               *Property[Double]                   `json:"outlineWidth,omitempty"`
+	TranslucencyByDistance     *Property[NearFarScaler]            `json:"translucencyByDistance,omitempty"`
+	PixelOffsetScaleByDistance *Property[NearFarScaler]            `json:"pixelOffsetScaleByDistance,omitempty"`
+	ScaleByDistance            *Property[NearFarScaler]            `json:"scaleByDistance,omitempty"`
+	DistanceDisplayCondition   *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	DisableDepthTestDistance   *Property[Double]                   `json:"disableDepthTestDistance,omitempty"`
 }
 
 // BackgroundPadding describes the amount of horizontal and vertical padding, in pixels, between a
 // label's text and its background.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/BackgroundPadding
 type BackgroundPadding struct {
+	Interpolatable
 	Cartesian2 *Cartesian2Value `json:"cartesian2,omitempty"`
 	Reference  ReferenceValue   `json:"reference,omitempty"`
 }
@@ -57,3 +58,9 @@ type LabelStyle struct {
 // valid values are `FILL`, `OUTLINE`, and `FILL_AND_OUTLINE`
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/LabelStyleValue
 type LabelStyleValue string
+
+const (
+	LabelStyleFill           LabelStyleValue = "FILL"
+	LabelStyleOutline        LabelStyleValue = "OUTLINE"
+	LabelStyleFillAndOutline LabelStyleValue = "FILL_AND_OUTLINE"
+)
@@ -0,0 +1,137 @@
+package czml
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeStandard is a time scale in which clock readings can be given
+type TimeStandard string
+
+const (
+	// TimeStandardUTC is Coordinated Universal Time, which CZML dates are written in
+	TimeStandardUTC TimeStandard = "UTC"
+	// TimeStandardTAI is International Atomic Time, which is ahead of UTC by the accumulated leap
+	// seconds
+	TimeStandardTAI TimeStandard = "TAI"
+	// TimeStandardGPS is GPS time, which is 19 seconds behind TAI
+	TimeStandardGPS TimeStandard = "GPS"
+	// TimeStandardTT is Terrestrial Time, which is 32.184 seconds ahead of TAI
+	TimeStandardTT TimeStandard = "TT"
+)
+
+// leapSecond is the offset of TAI from UTC, in seconds, from a UTC date onward
+type leapSecond struct {
+	date   time.Time
+	offset int
+}
+
+// leapSeconds is the table of offsets of TAI from UTC, as published by the IERS and used by
+// Cesium. Dates before the first entry use its offset.
+var leapSeconds = []leapSecond{
+	{time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), 10},
+	{time.Date(1972, time.July, 1, 0, 0, 0, 0, time.UTC), 11},
+	{time.Date(1973, time.January, 1, 0, 0, 0, 0, time.UTC), 12},
+	{time.Date(1974, time.January, 1, 0, 0, 0, 0, time.UTC), 13},
+	{time.Date(1975, time.January, 1, 0, 0, 0, 0, time.UTC), 14},
+	{time.Date(1976, time.January, 1, 0, 0, 0, 0, time.UTC), 15},
+	{time.Date(1977, time.January, 1, 0, 0, 0, 0, time.UTC), 16},
+	{time.Date(1978, time.January, 1, 0, 0, 0, 0, time.UTC), 17},
+	{time.Date(1979, time.January, 1, 0, 0, 0, 0, time.UTC), 18},
+	{time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC), 19},
+	{time.Date(1981, time.July, 1, 0, 0, 0, 0, time.UTC), 20},
+	{time.Date(1982, time.July, 1, 0, 0, 0, 0, time.UTC), 21},
+	{time.Date(1983, time.July, 1, 0, 0, 0, 0, time.UTC), 22},
+	{time.Date(1985, time.July, 1, 0, 0, 0, 0, time.UTC), 23},
+	{time.Date(1988, time.January, 1, 0, 0, 0, 0, time.UTC), 24},
+	{time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC), 25},
+	{time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC), 26},
+	{time.Date(1992, time.July, 1, 0, 0, 0, 0, time.UTC), 27},
+	{time.Date(1993, time.July, 1, 0, 0, 0, 0, time.UTC), 28},
+	{time.Date(1994, time.July, 1, 0, 0, 0, 0, time.UTC), 29},
+	{time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC), 30},
+	{time.Date(1997, time.July, 1, 0, 0, 0, 0, time.UTC), 31},
+	{time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC), 32},
+	{time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC), 33},
+	{time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC), 34},
+	{time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC), 35},
+	{time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC), 36},
+	{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 37},
+}
+
+// LeapSeconds returns the offset of TAI from UTC, in seconds, at a UTC time
+func LeapSeconds(utc time.Time) int {
+	i := sort.Search(len(leapSeconds), func(i int) bool { return leapSeconds[i].date.After(utc) })
+	if i == 0 {
+		return leapSeconds[0].offset
+	}
+
+	return leapSeconds[i-1].offset
+}
+
+// offsetFromTAI returns the offset of s from TAI
+func (s TimeStandard) offsetFromTAI() time.Duration {
+	switch s {
+	case TimeStandardGPS:
+		return -19 * time.Second
+	case TimeStandardTT:
+		return 32184 * time.Millisecond
+	}
+
+	return 0
+}
+
+// FromUTC returns the reading of a clock in the time standard at a UTC time. The result is
+// labelled UTC, as time.Time has no notion of other time standards.
+func (s TimeStandard) FromUTC(utc time.Time) time.Time {
+	utc = utc.UTC()
+	if s == TimeStandardUTC || s == "" {
+		return utc
+	}
+
+	tai := utc.Add(time.Duration(LeapSeconds(utc)) * time.Second)
+	return tai.Add(s.offsetFromTAI())
+}
+
+// ToUTC returns the UTC time at which a clock in the time standard reads t. Readings that fall
+// within a leap second, which time.Time cannot represent, return the start of the following second.
+func (s TimeStandard) ToUTC(t time.Time) time.Time {
+	t = t.UTC()
+	if s == TimeStandardUTC || s == "" {
+		return t
+	}
+
+	tai := t.Add(-s.offsetFromTAI())
+	for i := len(leapSeconds) - 1; i > 0; i-- {
+		change := leapSeconds[i]
+		if !tai.Before(change.date.Add(time.Duration(change.offset) * time.Second)) {
+			return tai.Add(-time.Duration(change.offset) * time.Second)
+		}
+		if !tai.Add(-time.Duration(leapSeconds[i-1].offset) * time.Second).Before(change.date) {
+			return change.date
+		}
+	}
+
+	return tai.Add(-time.Duration(leapSeconds[0].offset) * time.Second)
+}
+
+// ConvertTime converts the reading of a clock in one time standard to the reading of a clock in
+// another at the same instant
+func ConvertTime(t time.Time, from, to TimeStandard) time.Time {
+	return to.FromUTC(from.ToUTC(t))
+}
+
+// NewJulianDateIn returns the JulianDate at which a clock in the time standard reads t
+func NewJulianDateIn(t time.Time, s TimeStandard) JulianDate {
+	return NewJulianDate(s.ToUTC(t))
+}
+
+// As returns the reading of a clock in the time standard at d
+func (d JulianDate) As(s TimeStandard) time.Time {
+	return s.FromUTC(d.Time)
+}
+
+// DateTagIn returns a TimeTag for the time at which a clock in the time standard reads t
+func DateTagIn(t time.Time, s TimeStandard) TimeTag {
+	return TimeTag{Date: NewJulianDateIn(t, s)}
+}
@@ -0,0 +1,71 @@
+package czml
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvertTimeAcrossLeapSecond(t *testing.T) {
+	// a leap second was inserted at the end of 2016-12-31, taking TAI - UTC from 36 to 37 seconds
+	utc := func(h, m, s int) time.Time { return time.Date(2016, 12, 31, h, m, s, 0, time.UTC) }
+	after := func(h, m, s int) time.Time { return time.Date(2017, 1, 1, h, m, s, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		from, to TimeStandard
+		want     time.Time
+	}{
+		{"UTC to TAI before", utc(23, 59, 59), TimeStandardUTC, TimeStandardTAI, after(0, 0, 35)},
+		{"UTC to TAI after", after(0, 0, 0), TimeStandardUTC, TimeStandardTAI, after(0, 0, 37)},
+		{"UTC to GPS before", utc(23, 59, 59), TimeStandardUTC, TimeStandardGPS, after(0, 0, 16)},
+		{"UTC to GPS after", after(0, 0, 0), TimeStandardUTC, TimeStandardGPS, after(0, 0, 18)},
+		{"UTC to TT", after(0, 0, 0), TimeStandardUTC, TimeStandardTT, after(0, 1, 9).Add(184 * time.Millisecond)},
+		{"TAI to UTC before", after(0, 0, 35), TimeStandardTAI, TimeStandardUTC, utc(23, 59, 59)},
+		{"TAI to UTC after", after(0, 0, 37), TimeStandardTAI, TimeStandardUTC, after(0, 0, 0)},
+		// 23:59:60 UTC cannot be represented, so it becomes the start of the following second
+		{"TAI to UTC within the leap second", after(0, 0, 36), TimeStandardTAI, TimeStandardUTC, after(0, 0, 0)},
+		{"GPS to UTC within the leap second", after(0, 0, 17), TimeStandardGPS, TimeStandardUTC, after(0, 0, 0)},
+		{"GPS to TAI", after(0, 0, 18), TimeStandardGPS, TimeStandardTAI, after(0, 0, 37)},
+		{"TT to GPS", after(0, 1, 9).Add(184 * time.Millisecond), TimeStandardTT, TimeStandardGPS, after(0, 0, 18)},
+		{"UTC to UTC", utc(12, 0, 0), TimeStandardUTC, TimeStandardUTC, utc(12, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertTime(tt.t, tt.from, tt.to); !got.Equal(tt.want) {
+				t.Errorf("time is %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecondsAcrossLeapSecond(t *testing.T) {
+	before := NewJulianDate(time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC))
+	after := NewJulianDate(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if got := after.SecondsSince(before); got != 2 {
+		t.Errorf("%g seconds elapsed across the leap second, want 2", got)
+	}
+	if got := before.AddSeconds(2); !got.Equal(after.Time) {
+		t.Errorf("2 seconds after %v is %v, want %v", before, got, after)
+	}
+
+	tests := []struct {
+		name string
+		utc  time.Time
+		want int
+	}{
+		{"before the table", time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+		{"before the leap second", before.Time, 36},
+		{"after the leap second", after.Time, 37},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LeapSeconds(tt.utc); got != tt.want {
+				t.Errorf("TAI - UTC is %d s, want %d s", got, tt.want)
+			}
+		})
+	}
+}
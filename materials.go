@@ -13,21 +13,22 @@ type Material struct {
 // SolidColorMaterial is a material that fills the surface with a solid color
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/SolidColorMaterial
 type SolidColorMaterial struct {
-	Color *Color `json:"color,omitempty"`
+	Color *Property[Color] `json:"color,omitempty"`
 }
 
 // ImageMaterial is a material that fills the surface with an image
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ImageMaterial
 type ImageMaterial struct {
-	Image       *Uri    `json:"image,omitempty"`
-	Repeat      *Repeat `json:"repeat,omitempty"`
-	Color       *Color  `json:"color,omitempty"`
-	Transparent *bool   `json:"transparent,omitempty"`
+	Image       *Property[Uri]    `json:"image,omitempty"`
+	Repeat      *Property[Repeat] `json:"repeat,omitempty"`
+	Color       *Property[Color]  `json:"color,omitempty"`
+	Transparent *Property[bool]   `json:"transparent,omitempty"`
 }
 
 // Repeat is the number of times an image repeats along each axis.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Repeat
 type Repeat struct {
+	Interpolatable
 	Cartesian2 *Cartesian2Value `json:"cartesian2,omitempty"`
 	Reference  ReferenceValue   `json:"reference,omitempty"`
 }
@@ -35,16 +36,17 @@ type Repeat struct {
 // GridMaterial is a material that fills the surface with a two-dimensional grid.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/GridMaterial
 type GridMaterial struct {
-	Color         *Color         `json:"color,omitempty"`
-	CellAlpha     *float64       `json:"cellAlpha,omitempty"`
-	LineCount     *LineCount     `json:"lineCount,omitempty"`
-	LineThickness *LineThickness `json:"lineThickness,omitempty"`
-	LineOffset    *LineOffset    `json:"lineOffset,omitempty"`
+	Color         *Property[Color]         `json:"color,omitempty"`
+	CellAlpha     *Property[Double]        `json:"cellAlpha,omitempty"`
+	LineCount     *Property[LineCount]     `json:"lineCount,omitempty"`
+	LineThickness *Property[LineThickness] `json:"lineThickness,omitempty"`
+	LineOffset    *Property[LineOffset]    `json:"lineOffset,omitempty"`
 }
 
 // LineCount is the number of grid lines along each axis
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/LineCount
 type LineCount struct {
+	Interpolatable
 	Cartesian2 *Cartesian2Value `json:"cartesian2,omitempty"`
 	Reference  ReferenceValue   `json:"reference,omitempty"`
 }
@@ -52,6 +54,7 @@ type LineCount struct {
 // LineThickness is the thickness of grid lines along each axis, in pixels
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/LineThickness
 type LineThickness struct {
+	Interpolatable
 	Cartesian2 *Cartesian2Value `json:"cartesian2,omitempty"`
 	Reference  ReferenceValue   `json:"reference,omitempty"`
 }
@@ -59,6 +62,7 @@ type LineThickness struct {
 // LineOffset is the offset of grid lines along each axis, as a percentage from 0 to 1
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/LineOffset
 type LineOffset struct {
+	Interpolatable
 	Cartesian2 *Cartesian2Value `json:"cartesian2,omitempty"`
 	Reference  ReferenceValue   `json:"reference,omitempty"`
 }
@@ -66,11 +70,11 @@ type LineOffset struct {
 // StripeMaterial is a material that fills the surface with alternating colors
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/StripeMaterial
 type StripeMaterial struct {
-	Orientation StripeOrientation `json:"orientation,omitempty"`
-	EvenColor   *Color            `json:"evenColor,omitempty"`
-	OddColor    *Color            `json:"oddColor,omitempty"`
-	Offset      *float64          `json:"offset,omitempty"`
-	Repeat      *float64          `json:"repeat,omitempty"`
+	Orientation *Property[StripeOrientation] `json:"orientation,omitempty"`
+	EvenColor   *Property[Color]             `json:"evenColor,omitempty"`
+	OddColor    *Property[Color]             `json:"oddColor,omitempty"`
+	Offset      *Property[Double]            `json:"offset,omitempty"`
+	Repeat      *Property[Double]            `json:"repeat,omitempty"`
 }
 
 // StripeOrientation describes the orientation of stripes in a stripe material
@@ -78,10 +82,15 @@ type StripeMaterial struct {
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/StripeOrientation
 type StripeOrientation string
 
+const (
+	StripeOrientationHorizontal StripeOrientation = "HORIZONTAL"
+	StripeOrientationVertical   StripeOrientation = "VERTICAL"
+)
+
 // CheckerboardMaterial is a material that fills the surface with a checkerboard pattern.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CheckerboardMaterial
 type CheckerboardMaterial struct {
-	EvenColor *Color  `json:"evenColor,omitempty"`
-	OddColor  *Color  `json:"oddColor,omitempty"`
-	Repeat    *Repeat `json:"repeat,omitempty"`
+	EvenColor *Property[Color]  `json:"evenColor,omitempty"`
+	OddColor  *Property[Color]  `json:"oddColor,omitempty"`
+	Repeat    *Property[Repeat] `json:"repeat,omitempty"`
 }
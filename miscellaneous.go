@@ -4,34 +4,72 @@ package czml
 // and to the right to place an element relative to an origin.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/PixelOffset
 type PixelOffset struct {
+	Interpolatable
 	Cartesian2 *Cartesian2Value `json:"cartesian2,omitempty"`
 	Reference  ReferenceValue   `json:"reference,omitempty"`
 }
 
-// ArcType is the type of arc
-// Valid values are `NONE`, `GEODESIC`, and `RHUMB`
+// ArcType holds the type of arc
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ArcType
 type ArcType struct {
-	ArcType   string          `json:"arcType,omitempty"`
+	ArcType   ArcTypeValue    `json:"arcType,omitempty"`
 	Reference *ReferenceValue `json:"reference,omitempty"`
 }
 
+// ArcTypeValue is the type of arc. An unset ArcTypeValue is GEODESIC.
+// Valid values are `NONE`, `GEODESIC`, and `RHUMB`
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ArcTypeValue
+type ArcTypeValue string
+
+const (
+	ArcTypeNone     ArcTypeValue = "NONE"
+	ArcTypeGeodesic ArcTypeValue = "GEODESIC"
+	ArcTypeRhumb    ArcTypeValue = "RHUMB"
+)
+
 // ShadowMode specifies whether or not an object casts or receives shadows from each light source
 // when shadows are enabled.
 // Valid values are `DISABLED`, `ENABLED`, `CAST_ONLY`, and `RECEIVE_ONLY`
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ShadowMode
 type ShadowMode string
 
+const (
+	ShadowModeDisabled    ShadowMode = "DISABLED"
+	ShadowModeEnabled     ShadowMode = "ENABLED"
+	ShadowModeCastOnly    ShadowMode = "CAST_ONLY"
+	ShadowModeReceiveOnly ShadowMode = "RECEIVE_ONLY"
+)
+
 // DistanceDisplayCondition indicates the visibility of an object based on the distance to the
-// camera. The value is specified as two values [NearDistance, FarDistance], in meters.
+// camera, which can optionally vary over time.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/DistanceDisplayCondition
-type DistanceDisplayCondition []float64
+type DistanceDisplayCondition struct {
+	Interpolatable
+	DistanceDisplayCondition *DistanceDisplayConditionValue `json:"distanceDisplayCondition,omitempty"`
+	Reference                ReferenceValue                 `json:"reference,omitempty"`
+}
+
+// DistanceDisplayConditionValue is a distance display condition specified as two values
+// [NearDistance, FarDistance], in meters. If the array has two elements, the value is constant. If
+// it has three or more elements, they are time-tagged samples arranged as [Time, NearDistance,
+// FarDistance, Time, NearDistance, FarDistance, ...], where Time is an ISO 8601 date and time
+// string or seconds since epoch.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/DistanceDisplayConditionValue
+type DistanceDisplayConditionValue struct {
+	SampledValue
+}
 
 // ClassificationType specifies whether a classification affects terrain, 3D tiles, or both.
 // Valid values are `TERRAIN`, `CESIUM_3D_TILE`, and `BOTH`
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ClassificationTypeValue
 type ClassificationType string
 
+const (
+	ClassificationTypeTerrain      ClassificationType = "TERRAIN"
+	ClassificationTypeCesium3DTile ClassificationType = "CESIUM_3D_TILE"
+	ClassificationTypeBoth         ClassificationType = "BOTH"
+)
+
 // ReferenceValue represents a reference to another property. References can be used to specify
 // that two properties on different objects are in fact, the same property
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ReferenceValue
@@ -42,6 +80,7 @@ type ReferenceValue string
 // relative to the object's position, but may use another frame depending on the object's velocity.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ViewFrom
 type ViewFrom struct {
+	Interpolatable
 	Cartesian *Cartesian3Value `json:"cartesian,omitempty"`
 	Reference ReferenceValue   `json:"reference,omitempty"`
 }
@@ -59,16 +98,22 @@ type VerticalOrigin struct {
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/VerticalOriginValue
 type VerticalOriginValue string
 
+const (
+	VerticalOriginBaseline VerticalOriginValue = "BASELINE"
+	VerticalOriginBottom   VerticalOriginValue = "BOTTOM"
+	VerticalOriginCenter   VerticalOriginValue = "CENTER"
+	VerticalOriginTop      VerticalOriginValue = "TOP"
+)
+
 // VelocityRefernceValue is the normalized velocity vector of a position property. The reference
 // must be to a `position` property
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/VelocityReferenceValue
 type VelocityReferenceValue string
 
-// Uri holds a URI value. The URI can optionally vary with time.
+// Uri holds a URI value
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Uri
 type Uri struct {
 	Uri       *UriValue      `json:"uri,omitempty"`
-	Interval  string         `json:"interval,omitempty"`
 	Reference ReferenceValue `json:"reference,omitempty"`
 }
 
@@ -83,7 +128,9 @@ type UriValue string
 // as [Time, Clock, Cone, Time, Clock, Cone, ...], where Time is an ISO 8601 date and time string
 // or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/UnitSphericalValue
-type UnitSphericalValue []interface{}
+type UnitSphericalValue struct {
+	SampledValue
+}
 
 // UnitQuaternionValue is set of 4-dimensional coordinates used to represent rotation in
 // 3-dimensional space, specified as [X, Y, Z, W]. If the array has four elements, the value is
@@ -91,16 +138,18 @@ type UnitSphericalValue []interface{}
 // [Time, X, Y, Z, W, Time, X, Y, Z, W, ...], where Time is an ISO 8601 date and time string or
 // seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/UnitQuaternionValue
-type UnitQuaternionValue []float64
+type UnitQuaternionValue struct {
+	SampledValue
+}
 
 // Clock defines a simulated clock when a document is loaded
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Clock
 type Clock struct {
-	Interval    string   `json:"interval,omitempty"`
-	CurrentTime string   `json:"currentTime,omitempty"`
-	Multiplier  *float64 `json:"multiplier,omitempty"`
-	Range       string   `json:"range,omitempty"`
-	Step        string   `json:"step,omitempty"`
+	Interval    *TimeInterval `json:"interval,omitempty"`
+	CurrentTime *JulianDate   `json:"currentTime,omitempty"`
+	Multiplier  *float64      `json:"multiplier,omitempty"`
+	Range       string        `json:"range,omitempty"`
+	Step        string        `json:"step,omitempty"`
 }
 
 // NearFarScalar holds a numeric value which will be linearly *interpolated between two values based
@@ -110,6 +159,7 @@ type Clock struct {
 // less than the near distance or greater than the far distance, respectively.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/NearFarScalar
 type NearFarScaler struct {
+	Interpolatable
 	NearFarScalar *NearFarScalarValue `json:"nearFarScalar,omitempty"`
 	Reference     ReferenceValue      `json:"reference,omitempty"`
 }
@@ -120,11 +170,25 @@ type NearFarScaler struct {
 // FarDistance, FarValue, Time, NearDistance, NearValue, FarDistance, FarValue, ...], where Time is
 // an ISO 8601 date and time string or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/NearFarScalarValue
-type NearFarScalarValue []interface{}
+type NearFarScalarValue struct {
+	SampledValue
+}
 
-// TimeIntervalCollection can be a single string or an array of strings
-// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/TimeIntervalCollection
-type TimeIntervalCollection string
+// Double is a floating-point number, which can optionally vary over time
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Double
+type Double struct {
+	Interpolatable
+	Number    *DoubleValue   `json:"number,omitempty"`
+	Reference ReferenceValue `json:"reference,omitempty"`
+}
+
+// DoubleValue is a number. A constant is written as a single number, and samples are written as
+// [Time, Value, Time, Value, ...], where Time is an ISO 8601 date and time string or seconds since
+// epoch.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/DoubleValue
+type DoubleValue struct {
+	SampledValue
+}
 
 // HeightReference holds the height reference of an object, which indicates if the object's position
 // is relative to terrain or not.
@@ -136,9 +200,16 @@ type HeightReference struct {
 
 // HeightReferenceValue is height reference of an object, which indicates if the object's position
 // is relative to terrain or not.
+// Valid values are `NONE`, `CLAMP_TO_GROUND`, and `RELATIVE_TO_GROUND`
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/HeightReferenceValue
 type HeightReferenceValue string
 
+const (
+	HeightReferenceNone             HeightReferenceValue = "NONE"
+	HeightReferenceClampToGround    HeightReferenceValue = "CLAMP_TO_GROUND"
+	HeightReferenceRelativeToGround HeightReferenceValue = "RELATIVE_TO_GROUND"
+)
+
 // HorizontalOrigin is the horizontal origin of an element, which can optionally vary over time. It
 // controls whether the element is left-, center-, or right-aligned with the position.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/HorizontalOrigin
@@ -152,18 +223,26 @@ type HorizontalOrigin struct {
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/HorizontalOriginValue
 type HorizontalOriginValue string
 
+const (
+	HorizontalOriginLeft   HorizontalOriginValue = "LEFT"
+	HorizontalOriginCenter HorizontalOriginValue = "CENTER"
+	HorizontalOriginRight  HorizontalOriginValue = "RIGHT"
+)
+
 // EyeOffset is an offset in eye coordinates which can optionally vary over time. Eye coordinates
 // are a left-handed coordinate system where the X-axis points toward the viewer's right, the Y-axis
 // points up, and the Z-axis points *into the screen.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/EyeOffset
 type EyeOffset struct {
+	Interpolatable
 	Cartesian *Cartesian3Value `json:"cartesian,omitempty"`
 	Reference ReferenceValue   `json:"reference,omitempty"`
 }
 
-// CustomProperties represents a key-value mapping
+// CustomProperties represents a key-value mapping. Values are kept as decoded JSON so that they
+// survive a round trip unchanged.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CustomProperties
-type CustomProperties map[string]struct{}
+type CustomProperties map[string]interface{}
 
 // ReferenceListOfListsValue is a list of lists of references to other properties
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ReferenceListOfListsValue
@@ -201,6 +280,7 @@ type ReferenceListValue []string
 // AlignedAxis is an aligned axis represented by a unit vector which can optionally vary over time
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/AlignedAxis
 type AlignedAxis struct {
+	Interpolatable
 	UnitCartesian     *UnitCartesian3Value    `json:"unitCartesian,omitempty"`
 	UnitSpherical     *UnitSphericalValue     `json:"unitSpherical,omitempty"`
 	Reference         ReferenceValue          `json:"reference,omitempty"`
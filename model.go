@@ -3,24 +3,24 @@ package czml
 // Model describes a 3D model
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Model
 type Model struct {
-	Show                      *bool                     `json:"show,omitempty"`
-	Gltf                      *Uri                      `json:"uri"`
-	Scale                     *float64                  `json:"scale,omitempty"`
-	MinimumPixelSize          *float64                  `json:"minimumPixelSize,omitempty"`
-	MaximumScale              *float64                  `json:"maximumScale,omitempty"`
-	MinimumCone               *float64                  `json:"minimumCone,omitempty"`
-	IncrementallyLoadTextures *bool                     `json:"incrementallyLoadTextures,omitempty"`
-	RunAnimations             *bool                     `json:"runAnimations,omitempty"`
-	Shadows                   ShadowMode                `json:"shadows,omitempty"`
-	HeightReference           *HeightReference          `json:"heightReference,omitempty"`
-	SilhouetteColor           *Color                    `json:"silhouetteColor,omitempty"`
-	SilhouetteSize            *float64                  `json:"silhouetteSize,omitempty"`
-	Color                     *Color                    `json:"color,omitempty"`
-	ColorBlendMode            *ColorBlendMode           `json:"colorBlendMode,omitempty"`
-	ColorBlendAmount          *float64                  `json:"colorBlendAmount,omitempty"`
-	DistanceDisplayCondition  *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	NodeTransformations       *NodeTransformations      `json:"nodeTransformations,omitempty"`
-	Articulations             *Articulations            `json:"articulations,omitempty"`
+	Show                      *Property[bool]                     `json:"show,omitempty"`
+	Gltf                      *Property[Uri]                      `json:"uri"`
+	Scale                     *Property[Double]                   `json:"scale,omitempty"`
+	MinimumPixelSize          *Property[Double]                   `json:"minimumPixelSize,omitempty"`
+	MaximumScale              *Property[Double]                   `json:"maximumScale,omitempty"`
+	MinimumCone               *Property[Double]                   `json:"minimumCone,omitempty"`
+	IncrementallyLoadTextures *Property[bool]                     `json:"incrementallyLoadTextures,omitempty"`
+	RunAnimations             *Property[bool]                     `json:"runAnimations,omitempty"`
+	Shadows                   *Property[ShadowMode]               `json:"shadows,omitempty"`
+	HeightReference           *Property[HeightReference]          `json:"heightReference,omitempty"`
+	SilhouetteColor           *Property[Color]                    `json:"silhouetteColor,omitempty"`
+	SilhouetteSize            *Property[Double]                   `json:"silhouetteSize,omitempty"`
+	Color                     *Property[Color]                    `json:"color,omitempty"`
+	ColorBlendMode            *Property[ColorBlendMode]           `json:"colorBlendMode,omitempty"`
+	ColorBlendAmount          *Property[Double]                   `json:"colorBlendAmount,omitempty"`
+	DistanceDisplayCondition  *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	NodeTransformations       *NodeTransformations                `json:"nodeTransformations,omitempty"`
+	Articulations             *Articulations                      `json:"articulations,omitempty"`
 }
 
 // Articulations is a mapping of keys to articulation values, where the keys are the name of the
@@ -43,3 +43,9 @@ type ColorBlendMode struct {
 // Valid values are `HIGHLIGHT`, `REPLACE`, and `MIX`
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ColorBlendModeValue
 type ColorBlendModeValue string
+
+const (
+	ColorBlendModeHighlight ColorBlendModeValue = "HIGHLIGHT"
+	ColorBlendModeReplace   ColorBlendModeValue = "REPLACE"
+	ColorBlendModeMix       ColorBlendModeValue = "MIX"
+)
@@ -1,10 +1,154 @@
 package czml
 
+import (
+	"errors"
+	"math"
+	"time"
+)
+
 // Orientation is a rotation that takes a vector expresxsed in the "body" axes of the object and
 // transforms it to the Earth fixed axes.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Orientation
 type Orientation struct {
+	Interpolatable
 	UnitQuaternion    *UnitQuaternionValue    `json:"unitQuaternion,omitempty"`
 	Reference         ReferenceValue          `json:"reference,omitempty"`
 	VelocityReference *VelocityReferenceValue `json:"velocityReference,omitempty"`
 }
+
+// velocityStep is the time step, in seconds, used to estimate velocity from positions, as Cesium's
+// VelocityVectorProperty does
+const velocityStep = 1.0 / 60.0
+
+// OrientAlongVelocity orients the entity along the velocity of its position by setting the
+// orientation to the velocity reference "#position". Models then point their +X axis along their
+// direction of travel, with +Z away from the ellipsoid.
+func (p *Packet) OrientAlongVelocity() error {
+	if p.Position == nil {
+		return errors.New("position must be set before orienting along velocity")
+	}
+
+	velocity := VelocityReferenceValue(Ref("", "position"))
+	p.Orientation = NewProperty(Orientation{VelocityReference: &velocity})
+	return nil
+}
+
+// VelocityOrientation returns an orientation with a unit quaternion sample at each sample of the
+// position, turning the body +X axis along the velocity and +Z toward up, the same way Cesium
+// evaluates a "#position" velocity reference. It is for clients that do not support velocity
+// references. up is the Earth-fixed direction the body +Z axis leans toward; a zero up uses the
+// normal to the WGS84 ellipsoid below the position, as Cesium does.
+func (p *Position) VelocityOrientation(up [3]float64) (*Orientation, error) {
+	v, ok := p.sampledValue()
+	if !ok || !v.IsSampled() {
+		return nil, errors.New("czml: position must be sampled to have a velocity")
+	}
+
+	samples, times, err := p.sortedSamples(v)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Orientation{UnitQuaternion: &UnitQuaternionValue{}}
+	for j, t := range times {
+		position, velocity, err := p.cartesianAndVelocity(t)
+		if err != nil {
+			return nil, err
+		}
+
+		reference := cartesian(up)
+		if reference == (cartesian{}) {
+			reference = geodeticNormal(position)
+		}
+
+		q := quaternionFromRotation(velocityRotation(velocity, reference))
+		o.UnitQuaternion.AddQuaternion(samples[j].Time, q)
+	}
+
+	o.Epoch = p.Epoch
+	return o, nil
+}
+
+// sampledValue returns the value of the position in whichever representation it is given, or false
+// if it is a reference
+func (p *Position) sampledValue() (SampledValue, bool) {
+	switch {
+	case p.CartesianVelocity != nil:
+		return p.CartesianVelocity.SampledValue, true
+	case p.Cartesian != nil:
+		return p.Cartesian.SampledValue, true
+	case p.CartographicRadians != nil:
+		return p.CartographicRadians.SampledValue, true
+	case p.CartographicDegrees != nil:
+		return p.CartographicDegrees.SampledValue, true
+	}
+
+	return SampledValue{}, false
+}
+
+// cartesianAt returns the Earth-fixed position at a time
+func (p *Position) cartesianAt(at time.Time) (cartesian, error) {
+	value, err := p.ValueAt(at)
+	if err != nil {
+		return cartesian{}, err
+	}
+
+	return p.cartesianOf(value), nil
+}
+
+// cartesianOf returns the Earth-fixed position of a value in the representation of the position
+func (p *Position) cartesianOf(value []float64) cartesian {
+	switch {
+	case p.CartographicRadians != nil:
+		return CartographicToCartesian(value[0], value[1], value[2])
+	case p.CartographicDegrees != nil:
+		return CartographicToCartesian(value[0]*math.Pi/180, value[1]*math.Pi/180, value[2])
+	}
+
+	return cartesian{value[0], value[1], value[2]}
+}
+
+// cartesianAndVelocity returns the Earth-fixed position and velocity at a time. Velocities given
+// with the position are used as is, and others are estimated from the positions a short step apart.
+func (p *Position) cartesianAndVelocity(at time.Time) (cartesian, cartesian, error) {
+	if p.CartesianVelocity != nil {
+		value, err := p.ValueAt(at)
+		if err != nil {
+			return cartesian{}, cartesian{}, err
+		}
+		if len(value) == 6 {
+			return cartesian{value[0], value[1], value[2]}, cartesian{value[3], value[4], value[5]}, nil
+		}
+	}
+
+	position, err := p.cartesianAt(at)
+	if err != nil {
+		return cartesian{}, cartesian{}, err
+	}
+
+	// step forward, or backward at the end of the samples
+	step := velocityStep
+	next, err := p.cartesianAt(at.Add(seconds(step)))
+	if err != nil {
+		step = -velocityStep
+		if next, err = p.cartesianAt(at.Add(seconds(step))); err != nil {
+			return cartesian{}, cartesian{}, err
+		}
+	}
+
+	return position, next.sub(position).scale(1 / step), nil
+}
+
+// velocityRotation returns the rotation matrix, by columns, whose X axis is along the velocity and
+// whose Z axis is toward up, following Cesium's Transforms.rotationMatrixFromPositionVelocity
+func velocityRotation(velocity, up cartesian) [3]cartesian {
+	x := velocity.normalize()
+	y := x.cross(up)
+	if y.length() < 1e-6 {
+		y = cartesian{1, 0, 0}
+	}
+	z := y.cross(x).normalize()
+	y = x.cross(z).scale(-1).normalize()
+
+	return [3]cartesian{x, y, z}
+}
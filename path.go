@@ -6,31 +6,26 @@ package czml
 // using the leadTime and trailTime properties.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Path
 type Path struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	LeadTime                 *float64                  `json:"leadTime,omitempty"`
-	TrailTime                *float64                  `json:"trailTime,omitempty"`
-	Width                    *float64                  `json:"width,omitempty"`
-	Resolution               *float64                  `json:"resolution,omitempty"`
-	Material                 *PolylineMaterial         `json:"material,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	LeadTime                 *Property[Double]                   `json:"leadTime,omitempty"`
+	TrailTime                *Property[Double]                   `json:"trailTime,omitempty"`
+	Width                    *Property[Double]                   `json:"width,omitempty"`
+	Resolution               *Property[Double]                   `json:"resolution,omitempty"`
+	Material                 *Property[PolylineMaterial]         `json:"material,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
 }
 
 // UpdateColor adds or updates a solid-colored line specified by rgba value
 func (p *Path) UpdateColor(rgba []int) {
-	color := Color{Rgba: rgba}
-	show := true
-	width := float64(5)
-	leadTime := float64(0)
-	trailTime := float64(1000000000)
-	resolution := float64(10)
-	colorMaterial := SolidColorMaterial{Color: &color}
+	color := Color{Rgba: rgbaValue(rgba)}
+	colorMaterial := SolidColorMaterial{Color: NewProperty(color)}
 	material := PolylineMaterial{
 		SolidColor: &colorMaterial,
 	}
-	p.Show = &show
-	p.Width = &width
-	p.LeadTime = &leadTime
-	p.TrailTime = &trailTime
-	p.Resolution = &resolution
-	p.Material = &material
+	p.Show = NewProperty(true)
+	p.Width = NewDouble(5)
+	p.LeadTime = NewDouble(0)
+	p.TrailTime = NewDouble(1000000000)
+	p.Resolution = NewDouble(10)
+	p.Material = NewProperty(material)
 }
@@ -3,54 +3,55 @@ package czml
 // Polyline is a line in the scene composed of multiple segments.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Polyline
 type Polyline struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Positions                *PositionList             `json:"positions"`
-	ArcType                  *ArcType                  `json:"arcType,omitempty"`
-	Width                    *float64                  `json:"width,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Material                 *PolylineMaterial         `json:"material,omitempty"`
-	FollowSurface            *bool                     `json:"followSurface,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DepthFailMaterial        *PolylineMaterial         `json:"depthFailMaterial,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	ClampToGround            *bool                     `json:"clampToGround,omitempty"`
-	ClassificationType       ClassificationType        `json:"classificationType,omitempty"`
-	ZIndex                   *int                      `json:"zIndex,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Positions                *Property[PositionList]             `json:"positions"`
+	ArcType                  *Property[ArcType]                  `json:"arcType,omitempty"`
+	Width                    *Property[Double]                   `json:"width,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Material                 *Property[PolylineMaterial]         `json:"material,omitempty"`
+	FollowSurface            *Property[bool]                     `json:"followSurface,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DepthFailMaterial        *Property[PolylineMaterial]         `json:"depthFailMaterial,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	ClampToGround            *Property[bool]                     `json:"clampToGround,omitempty"`
+	ClassificationType       *Property[ClassificationType]       `json:"classificationType,omitempty"`
+	ZIndex                   *Property[int]                      `json:"zIndex,omitempty"`
 }
 
 // PolylineVolume is a polyline with a volume, defined as a 2D shape extruded along a polyline
 // that conforms to the curvature of the globe.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/PolylineVolume
 type PolylineVolume struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Positions                *PositionList             `json:"positions"`
-	Shape                    *Shape                    `json:"shape"`
-	CornerType               *CornerType               `json:"cornerType,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *PolylineMaterial         `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Positions                *Property[PositionList]             `json:"positions"`
+	Shape                    *Property[Shape]                    `json:"shape"`
+	CornerType               *Property[CornerType]               `json:"cornerType,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[PolylineMaterial]         `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
 }
 
 // UpdateColor adds or updates a solid-colored line specified by rgba value
 func (p *Polyline) UpdateColor(rgba []int) {
-	c := Color{Rgba: rgba}
-	s := SolidColorMaterial{Color: &c}
+	c := Color{Rgba: rgbaValue(rgba)}
+	s := SolidColorMaterial{Color: NewProperty(c)}
 	m := PolylineMaterial{SolidColor: &s}
-	p.Material = &m
+	p.Material = NewProperty(m)
 }
 
 // AddPoint adds a geographical point
 func (p *Polyline) AddPoint(lat, lon, ele float64) {
 	if p.Positions == nil {
-		p.Positions = &PositionList{}
-	} else if p.Positions.CartographicDegrees == nil {
-		p.Positions.CartographicDegrees = []float64{}
+		p.Positions = &Property[PositionList]{}
+	}
+	if p.Positions.Value.CartographicDegrees == nil {
+		p.Positions.Value.CartographicDegrees = []float64{}
 	}
 
-	p.Positions.CartographicDegrees = append(p.Positions.CartographicDegrees, lon, lat, ele)
+	p.Positions.Value.CartographicDegrees = append(p.Positions.Value.CartographicDegrees, lon, lat, ele)
 }
@@ -21,30 +21,30 @@ func (m *PolylineMaterial) UpdateColor(c SolidColorMaterial) {
 // PolylineOutlineMaterial is a material that fills the surface of a line with an outlined color.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/PolylineOutlineMaterial
 type PolylineOutlineMaterial struct {
-	Color        *Color   `json:"color,omitempty"`
-	OutlineColor *Color   `json:"outlineColor,omitempty"`
-	OutlineWidth *float64 `json:"outlineWidth,omitempty"`
+	Color        *Property[Color]  `json:"color,omitempty"`
+	OutlineColor *Property[Color]  `json:"outlineColor,omitempty"`
+	OutlineWidth *Property[Double] `json:"outlineWidth,omitempty"`
 }
 
 // PolylineArrowMaterial is a material that fills the surface of a line with an arrow.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/PolylineArrowMaterial
 type PolylineArrowMaterial struct {
-	Color *Color `json:"color,omitempty"`
+	Color *Property[Color] `json:"color,omitempty"`
 }
 
 // PolylineDashMaterial is a material that fills the surface of a line with a pattern of dashes.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/PolylineDashMaterial
 type PolylineDashMaterial struct {
-	Color       *Color   `json:"color,omitempty"`
-	GapColor    *Color   `json:"gapColor,omitempty"`
-	DashLength  *float64 `json:"dashLength,omitempty"`
-	DashPattern *int     `json:"dashPattern,omitempty"`
+	Color       *Property[Color]  `json:"color,omitempty"`
+	GapColor    *Property[Color]  `json:"gapColor,omitempty"`
+	DashLength  *Property[Double] `json:"dashLength,omitempty"`
+	DashPattern *Property[int]    `json:"dashPattern,omitempty"`
 }
 
 // PolylineGlowMaterial is a material that fills the surface of a line with a glowing color.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/PolylineGlowMaterial
 type PolylineGlowMaterial struct {
-	Color      *Color   `json:"color,omitempty"`
-	GlowPower  *float64 `json:"glowPower,omitempty"`
-	TaperPower *float64 `json:"taperPower,omitempty"`
+	Color      *Property[Color]  `json:"color,omitempty"`
+	GlowPower  *Property[Double] `json:"glowPower,omitempty"`
+	TaperPower *Property[Double] `json:"taperPower,omitempty"`
 }
@@ -3,11 +3,11 @@ package czml
 // Position defines a position
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Position
 type Position struct {
-	Epoch               string                    `json:"epoch,omitempty"`
+	Interpolatable
 	ReferenceFrame      string                    `json:"referenceFrame,omitempty"`
 	Cartesian           *Cartesian3Value          `json:"cartesian,omitempty"`
 	CartographicRadians *CartographicRadiansValue `json:"cartographicRadians,omitempty"`
-	CartographicDegrees []string                  `json:"cartographicDegrees,omitempty"`
+	CartographicDegrees *CartographicDegreesValue `json:"cartographicDegrees,omitempty"`
 	CartesianVelocity   *Cartesian3VelocityValue  `json:"cartesianVelocity,omitempty"`
 	Reference           ReferenceValue            `json:"reference,omitempty"`
 }
@@ -18,7 +18,7 @@ type PositionList struct {
 	ReferenceFrame      string                        `json:"referenceFrame,omitempty"`
 	Cartesian           *Cartesian3ListValue          `json:"cartesian,omitempty"`
 	CartographicRadians *CartographicRadiansListValue `json:"cartographicRadians,omitempty"`
-	CartographicDegrees []float64                     `json:"cartographicDegrees,omitempty"`
+	CartographicDegrees CartographicDegreesListValue  `json:"cartographicDegrees,omitempty"`
 	References          *ReferenceListValue           `json:"references,omitempty"`
 }
 
@@ -0,0 +1,292 @@
+package czml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Property is a property whose value may change over time. It holds either a single Value, a
+// Reference to a property of another object, or a list of Intervals that each hold the value
+// applying during a time interval. In JSON, a single value is written as is, a reference as
+// {"reference": "id#property"}, and intervals are written as an array of objects such as
+// [{"interval": "2012-08-04T16:00:00Z/2012-08-04T17:00:00Z", "boolean": true}, ...].
+// Boolean, integer, string and URI values are constant within an interval. Objects such as a Color,
+// a Position or a Double can hold samples of their own within each interval.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CZML-Structure#time-varying-values
+type Property[T any] struct {
+	Value     T
+	Reference ReferenceValue
+	Intervals []IntervalValue[T]
+}
+
+// IntervalValue is a property value, or a reference, that applies during a time interval
+type IntervalValue[T any] struct {
+	Interval  TimeInterval
+	Value     T
+	Reference ReferenceValue
+}
+
+// NewProperty returns a Property holding a single value
+func NewProperty[T any](v T) *Property[T] {
+	return &Property[T]{Value: v}
+}
+
+// NewDouble returns a Property holding a single constant number
+func NewDouble(v float64) *Property[Double] {
+	return NewProperty(Double{Number: &DoubleValue{SampledValue{Value: []float64{v}}}})
+}
+
+// propertyOf returns a Property holding the value v points to, or nil if v is nil
+func propertyOf[T any](v *T) *Property[T] {
+	if v == nil {
+		return nil
+	}
+
+	return NewProperty(*v)
+}
+
+// AddInterval adds a value that applies during the provided interval
+func (p *Property[T]) AddInterval(interval TimeInterval, v T) {
+	p.Intervals = append(p.Intervals, IntervalValue[T]{Interval: interval, Value: v})
+}
+
+// HasIntervals reports whether the value of the property varies over time intervals
+func (p Property[T]) HasIntervals() bool {
+	return len(p.Intervals) > 0
+}
+
+// ValueAt returns the value active at the provided time. When intervals overlap, the one listed
+// last takes precedence, as it does in Cesium. It returns false if no interval contains the time,
+// or if the value at the time is a reference, which must be resolved first.
+func (p Property[T]) ValueAt(at time.Time) (T, bool) {
+	var zero T
+	if !p.HasIntervals() {
+		return p.Value, p.Reference == "" && !isReference(p.Value)
+	}
+
+	for i := len(p.Intervals) - 1; i >= 0; i-- {
+		if iv := p.Intervals[i]; iv.Interval.Contains(at) {
+			return iv.Value, iv.Reference == "" && !isReference(iv.Value)
+		}
+	}
+
+	return zero, false
+}
+
+// isReference reports whether a value is an object such as a Color holding only a reference
+func isReference(v interface{}) bool {
+	_, ok := referenceOf(reflect.ValueOf(v))
+	return ok
+}
+
+// propertyValuer is implemented by pointers to every Property and IntervalValue, giving reflection
+// access to their single value. propertyValue returns false when the property holds intervals or a
+// reference.
+type propertyValuer interface {
+	propertyValue() (reflect.Value, bool)
+}
+
+func (p *Property[T]) propertyValue() (reflect.Value, bool) {
+	return reflect.ValueOf(&p.Value).Elem(), !p.HasIntervals() && p.Reference == ""
+}
+
+func (iv *IntervalValue[T]) propertyValue() (reflect.Value, bool) {
+	return reflect.ValueOf(&iv.Value).Elem(), iv.Reference == ""
+}
+
+// MarshalJSON writes a single value as is, a reference as a reference object, and intervals as an
+// array of interval objects
+func (p Property[T]) MarshalJSON() ([]byte, error) {
+	if !p.HasIntervals() {
+		if p.Reference != "" {
+			return json.Marshal(map[string]ReferenceValue{"reference": p.Reference})
+		}
+		return json.Marshal(p.Value)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, iv := range p.Intervals {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		data, err := iv.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON reads a single value, a single value wrapped in an object such as
+// {"boolean": true}, a reference object, or an array of interval objects
+func (p *Property[T]) UnmarshalJSON(data []byte) error {
+	*p = Property[T]{}
+
+	var elements []json.RawMessage
+	if json.Unmarshal(data, &elements) == nil && len(elements) > 0 && isIntervalList(elements) {
+		p.Intervals = make([]IntervalValue[T], len(elements))
+		for i, e := range elements {
+			if err := p.Intervals[i].UnmarshalJSON(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return unmarshalPropertyValue(data, &p.Value, &p.Reference)
+}
+
+// MarshalJSON writes the interval and value as a single object. Values that are JSON objects have
+// the interval added to their fields, and other values are written under the key CZML uses for
+// their type, such as "boolean" or "number". A reference is written under "reference".
+func (iv IntervalValue[T]) MarshalJSON() ([]byte, error) {
+	value, err := json.Marshal(iv.Value)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := json.Marshal(iv.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"interval":`)
+	buf.Write(interval)
+
+	if iv.Reference != "" {
+		reference, err := json.Marshal(iv.Reference)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, `,"reference":%s}`, reference)
+		return buf.Bytes(), nil
+	}
+
+	if key := valueKey(reflect.TypeOf((*T)(nil)).Elem()); key != "" {
+		fmt.Fprintf(&buf, `,%q:%s}`, key, value)
+		return buf.Bytes(), nil
+	}
+
+	value = bytes.TrimSpace(value)
+	if len(value) < 2 || value[0] != '{' {
+		return nil, fmt.Errorf("czml: cannot write %s as an interval value", value)
+	}
+	if body := bytes.TrimSpace(value[1 : len(value)-1]); len(body) > 0 {
+		buf.WriteByte(',')
+		buf.Write(body)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON reads an interval object
+func (iv *IntervalValue[T]) UnmarshalJSON(data []byte) error {
+	var fields struct {
+		Interval TimeInterval `json:"interval"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	iv.Interval = fields.Interval
+	return unmarshalPropertyValue(data, &iv.Value, &iv.Reference)
+}
+
+// unmarshalPropertyValue reads a value either as is or from the object key CZML uses for its type.
+// Values of primitive types given as a reference are read into reference instead.
+func unmarshalPropertyValue(data []byte, v interface{}, reference *ReferenceValue) error {
+	key := valueKey(reflect.TypeOf(v).Elem())
+	if key != "" && len(data) > 0 && data[0] == '{' {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+
+		value, ok := fields[key]
+		if !ok {
+			if r, ok := fields["reference"]; ok {
+				return json.Unmarshal(r, reference)
+			}
+			return fmt.Errorf("czml: expected %q in %s", key, data)
+		}
+		if key == "number" && len(value) > 0 && value[0] == '[' {
+			return fmt.Errorf("czml: sampled numbers must be read into a Property[Double], found %s", data)
+		}
+		data = value
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// valueKey returns the key CZML uses for values of primitive types in interval objects, or an
+// empty string for types written as objects
+func valueKey(t reflect.Type) string {
+	if key, ok := valueKeys[t]; ok {
+		return key
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	}
+
+	return ""
+}
+
+// valueKeys holds the keys of primitive types that CZML names after the type rather than its kind
+var valueKeys = map[reflect.Type]string{
+	reflect.TypeOf(UriValue("")):                     "uri",
+	reflect.TypeOf(DoubleList(nil)):                  "array",
+	reflect.TypeOf(ShadowMode("")):                   "shadowMode",
+	reflect.TypeOf(ClassificationType("")):           "classificationType",
+	reflect.TypeOf(StripeOrientation("")):            "stripeOrientation",
+	reflect.TypeOf(SensorVolumePortionToDisplay("")): "portionToDisplay",
+}
+
+// isIntervalList reports whether every element of an array is an object with an interval
+func isIntervalList(elements []json.RawMessage) bool {
+	for _, e := range elements {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(e, &fields) != nil {
+			return false
+		}
+		if _, ok := fields["interval"]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnmarshalJSON reads a double given as a single number, or as an object such as
+// {"number": [Time, Value, ...], "interpolationAlgorithm": "LAGRANGE"}
+func (d *Double) UnmarshalJSON(data []byte) error {
+	var number float64
+	if json.Unmarshal(data, &number) == nil {
+		*d = Double{Number: &DoubleValue{SampledValue{Value: []float64{number}}}}
+		return nil
+	}
+
+	type double Double
+	var v double
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = Double(v)
+	return nil
+}
@@ -0,0 +1,93 @@
+package czml
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPropertyIntervals(t *testing.T) {
+	const (
+		first  = `"interval":"2020-01-01T00:00:00Z/2020-01-01T01:00:00Z"`
+		second = `"interval":"2020-01-01T01:00:00Z/2020-01-01T02:00:00Z"`
+	)
+	at := time.Date(2020, 1, 1, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		packet string
+		value  func(p Packet) (interface{}, bool)
+		want   string // the value at 01:30, written as JSON
+	}{
+		{"color",
+			`{"id":"e","billboard":{"color":[{` + first + `,"rgba":[255,0,0,255]},{` + second + `,"rgba":[0,255,0,255]}]}}`,
+			func(p Packet) (interface{}, bool) { return p.Billboard.Color.ValueAt(at) },
+			`{"rgba":[0,255,0,255]}`},
+		{"position",
+			`{"id":"e","position":[{` + first + `,"cartographicDegrees":[1,2,3]},{` + second + `,"cartographicDegrees":[4,5,6]}]}`,
+			func(p Packet) (interface{}, bool) { return p.Position.ValueAt(at) },
+			`{"cartographicDegrees":[4,5,6]}`},
+		{"sampled position",
+			`{"id":"e","position":[{` + first + `,"cartesian":[0,1,2,3,60,4,5,6]},{` + second + `,"reference":"other#position"}]}`,
+			func(p Packet) (interface{}, bool) {
+				return p.Position.ValueAt(at.Add(-time.Hour))
+			},
+			`{"cartesian":[0,1,2,3,60,4,5,6]}`},
+		{"material",
+			`{"id":"e","polygon":{"material":[{` + first + `,"solidColor":{"color":{"rgba":[255,0,0,255]}}},{` + second + `,"stripe":{"orientation":"VERTICAL"}}]}}`,
+			func(p Packet) (interface{}, bool) { return p.Polygon.Material.ValueAt(at) },
+			`{"stripe":{"orientation":"VERTICAL"}}`},
+		{"color within a material",
+			`{"id":"e","polyline":{"positions":null,"material":{"solidColor":{"color":[{` + first + `,"rgba":[255,0,0,255]},{` + second + `,"reference":"style#color"}]}}}}`,
+			func(p Packet) (interface{}, bool) {
+				return p.Polyline.Material.Value.SolidColor.Color.ValueAt(at.Add(-time.Hour))
+			},
+			`{"rgba":[255,0,0,255]}`},
+		{"enumeration",
+			`{"id":"e","model":{"uri":null,"shadows":[{` + first + `,"shadowMode":"ENABLED"},{` + second + `,"shadowMode":"CAST_ONLY"}]}}`,
+			func(p Packet) (interface{}, bool) { return p.Model.Shadows.ValueAt(at) },
+			`"CAST_ONLY"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Packet
+			if err := json.Unmarshal([]byte(tt.packet), &p); err != nil {
+				t.Fatal(err)
+			}
+
+			value, ok := tt.value(p)
+			if !ok {
+				t.Fatal("no value")
+			}
+			if got := packetJSON(t, value); got != tt.want {
+				t.Errorf("value is %s, want %s", got, tt.want)
+			}
+			if got := packetJSON(t, p); got != tt.packet {
+				t.Errorf("packet is written as\n%s\nwant\n%s", got, tt.packet)
+			}
+		})
+	}
+}
+
+func TestPropertyValueAtReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		property string
+	}{
+		{"reference", `{"reference":"other#billboard.color"}`},
+		{"reference interval", `[{"interval":"2020-01-01T00:00:00Z/2020-01-02T00:00:00Z","reference":"other#billboard.color"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Property[Color]
+			if err := json.Unmarshal([]byte(tt.property), &p); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := p.ValueAt(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)); ok {
+				t.Error("a reference has a value before it is resolved")
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package czml
+
+import "math"
+
+// Quaternion is a rotation in 3-dimensional space, in the [X, Y, Z, W] order CZML uses for
+// unitQuaternion values. Rotations are applied to vectors as q * v * q⁻¹.
+type Quaternion struct {
+	X, Y, Z, W float64
+}
+
+// IdentityQuaternion is the rotation that leaves every vector unchanged
+var IdentityQuaternion = Quaternion{W: 1}
+
+// QuaternionFromAxisAngle returns the rotation by an angle in radians about an axis, counter-clockwise
+// when looking down the axis toward the origin
+func QuaternionFromAxisAngle(axis [3]float64, angle float64) Quaternion {
+	a := cartesian(axis).normalize()
+	s, c := math.Sincos(angle / 2)
+
+	return Quaternion{X: a[0] * s, Y: a[1] * s, Z: a[2] * s, W: c}
+}
+
+// QuaternionFromHeadingPitchRoll returns the Earth-fixed orientation of a body given its heading,
+// pitch and roll in radians in the local East-North-Up frame at a WGS84 longitude and latitude in
+// radians. It matches Cesium's Transforms.headingPitchRollQuaternion: heading turns the body +X
+// axis clockwise from east, pitch raises it above the horizon and roll turns the body about it.
+// A compass heading, measured clockwise from north, is passed as compass - π/2.
+func QuaternionFromHeadingPitchRoll(lon, lat, heading, pitch, roll float64) Quaternion {
+	local := QuaternionFromAxisAngle([3]float64{0, 0, 1}, -heading).
+		Multiply(QuaternionFromAxisAngle([3]float64{0, 1, 0}, -pitch)).
+		Multiply(QuaternionFromAxisAngle([3]float64{1, 0, 0}, roll))
+
+	return eastNorthUp(lon, lat).Multiply(local).Normalize()
+}
+
+// eastNorthUp returns the rotation from the local East-North-Up frame at a WGS84 longitude and
+// latitude in radians to Earth-fixed axes
+func eastNorthUp(lon, lat float64) Quaternion {
+	sinLat, cosLat := math.Sincos(lat)
+	sinLon, cosLon := math.Sincos(lon)
+
+	return quaternionFromRotation([3]cartesian{
+		{-sinLon, cosLon, 0},
+		{-sinLat * cosLon, -sinLat * sinLon, cosLat},
+		{cosLat * cosLon, cosLat * sinLon, sinLat},
+	})
+}
+
+// Multiply returns the rotation applying r and then q
+func (q Quaternion) Multiply(r Quaternion) Quaternion {
+	return Quaternion{
+		X: q.W*r.X + q.X*r.W + q.Y*r.Z - q.Z*r.Y,
+		Y: q.W*r.Y - q.X*r.Z + q.Y*r.W + q.Z*r.X,
+		Z: q.W*r.Z + q.X*r.Y - q.Y*r.X + q.Z*r.W,
+		W: q.W*r.W - q.X*r.X - q.Y*r.Y - q.Z*r.Z,
+	}
+}
+
+// Conjugate returns the inverse rotation of a unit quaternion
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{X: -q.X, Y: -q.Y, Z: -q.Z, W: q.W}
+}
+
+// Norm returns the magnitude of the quaternion
+func (q Quaternion) Norm() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Dot returns the dot product of two quaternions
+func (q Quaternion) Dot(r Quaternion) float64 {
+	return q.X*r.X + q.Y*r.Y + q.Z*r.Z + q.W*r.W
+}
+
+// Normalize returns the quaternion scaled to unit length. The zero quaternion is returned unchanged.
+func (q Quaternion) Normalize() Quaternion {
+	n := q.Norm()
+	if n == 0 {
+		return q
+	}
+
+	return Quaternion{X: q.X / n, Y: q.Y / n, Z: q.Z / n, W: q.W / n}
+}
+
+// Rotate returns a vector rotated by the unit quaternion
+func (q Quaternion) Rotate(v [3]float64) [3]float64 {
+	r := q.Multiply(Quaternion{X: v[0], Y: v[1], Z: v[2]}).Multiply(q.Conjugate())
+	return [3]float64{r.X, r.Y, r.Z}
+}
+
+// Values returns the quaternion as the [X, Y, Z, W] values of a unitQuaternion
+func (q Quaternion) Values() []float64 {
+	return []float64{q.X, q.Y, q.Z, q.W}
+}
+
+// Slerp returns the rotation a fraction t of the way from a to b along the shortest arc between
+// them, using spherical linear interpolation
+func Slerp(a, b Quaternion, t float64) Quaternion {
+	d := a.Dot(b)
+	if d < 0 {
+		b, d = Quaternion{X: -b.X, Y: -b.Y, Z: -b.Z, W: -b.W}, -d
+	}
+
+	wa, wb := 1-t, t
+	if d < 1-1e-9 {
+		theta := math.Acos(d)
+		sin := math.Sin(theta)
+		wa, wb = math.Sin((1-t)*theta)/sin, math.Sin(t*theta)/sin
+	}
+
+	return Quaternion{
+		X: wa*a.X + wb*b.X,
+		Y: wa*a.Y + wb*b.Y,
+		Z: wa*a.Z + wb*b.Z,
+		W: wa*a.W + wb*b.W,
+	}.Normalize()
+}
+
+// quaternionFromRotation returns the unit quaternion of a rotation matrix given by columns
+func quaternionFromRotation(m [3]cartesian) Quaternion {
+	// m[c][r] is the element at row r and column c
+	trace := m[0][0] + m[1][1] + m[2][2]
+	if trace > 0 {
+		s := 0.5 / math.Sqrt(trace+1)
+		return Quaternion{
+			X: (m[1][2] - m[2][1]) * s,
+			Y: (m[2][0] - m[0][2]) * s,
+			Z: (m[0][1] - m[1][0]) * s,
+			W: 0.25 / s,
+		}
+	}
+
+	switch {
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		return Quaternion{X: 0.25 * s, Y: (m[1][0] + m[0][1]) / s, Z: (m[2][0] + m[0][2]) / s, W: (m[1][2] - m[2][1]) / s}
+	case m[1][1] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		return Quaternion{X: (m[1][0] + m[0][1]) / s, Y: 0.25 * s, Z: (m[2][1] + m[1][2]) / s, W: (m[2][0] - m[0][2]) / s}
+	default:
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		return Quaternion{X: (m[2][0] + m[0][2]) / s, Y: (m[2][1] + m[1][2]) / s, Z: 0.25 * s, W: (m[0][1] - m[1][0]) / s}
+	}
+}
+
+// NewUnitQuaternionValue returns a constant unit quaternion value
+func NewUnitQuaternionValue(q Quaternion) *UnitQuaternionValue {
+	return &UnitQuaternionValue{SampledValue{Value: q.Values()}}
+}
+
+// AddQuaternion appends a time-tagged rotation to the value
+func (v *UnitQuaternionValue) AddQuaternion(t TimeTag, q Quaternion) {
+	v.AddSample(t, q.Values()...)
+}
+
+// Quaternion returns the rotation of a constant value
+func (v UnitQuaternionValue) Quaternion() (Quaternion, bool) {
+	if len(v.Value) != 4 {
+		return Quaternion{}, false
+	}
+
+	return Quaternion{X: v.Value[0], Y: v.Value[1], Z: v.Value[2], W: v.Value[3]}, true
+}
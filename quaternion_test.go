@@ -0,0 +1,122 @@
+package czml
+
+import (
+	"math"
+	"testing"
+)
+
+// sameRotation reports whether two unit quaternions give the same rotation, which q and -q both do
+func sameRotation(q, r Quaternion) bool {
+	return math.Abs(math.Abs(q.Dot(r))-1) < 1e-12
+}
+
+func closeVector(a, b [3]float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-12 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestQuaternionMultiply(t *testing.T) {
+	h := math.Sqrt(0.5)
+	x90 := QuaternionFromAxisAngle([3]float64{1, 0, 0}, math.Pi/2)
+	z90 := QuaternionFromAxisAngle([3]float64{0, 0, 1}, math.Pi/2)
+
+	tests := []struct {
+		name string
+		got  Quaternion
+		want Quaternion
+	}{
+		{"i j", Quaternion{X: 1}.Multiply(Quaternion{Y: 1}), Quaternion{Z: 1}},
+		{"j i", Quaternion{Y: 1}.Multiply(Quaternion{X: 1}), Quaternion{Z: -1}},
+		{"identity", z90.Multiply(IdentityQuaternion), z90},
+		{"axis angle", z90, Quaternion{Z: h, W: h}},
+		{"x then z", z90.Multiply(x90), Quaternion{X: 0.5, Y: 0.5, Z: 0.5, W: 0.5}},
+		{"twice", z90.Multiply(z90), Quaternion{Z: 1}},
+		{"conjugate", z90.Multiply(z90.Conjugate()), IdentityQuaternion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got.X-tt.want.X) > 1e-12 || math.Abs(tt.got.Y-tt.want.Y) > 1e-12 ||
+				math.Abs(tt.got.Z-tt.want.Z) > 1e-12 || math.Abs(tt.got.W-tt.want.W) > 1e-12 {
+				t.Errorf("quaternion is %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// the product applies x90 first: x stays on x, then turns to y
+	if got := z90.Multiply(x90).Rotate([3]float64{1, 0, 0}); !closeVector(got, [3]float64{0, 1, 0}) {
+		t.Errorf("x is rotated to %v, want [0 1 0]", got)
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	z := func(degrees float64) Quaternion {
+		return QuaternionFromAxisAngle([3]float64{0, 0, 1}, degrees*math.Pi/180)
+	}
+	negate := func(q Quaternion) Quaternion { return Quaternion{X: -q.X, Y: -q.Y, Z: -q.Z, W: -q.W} }
+
+	tests := []struct {
+		name string
+		a, b Quaternion
+		t    float64
+		want Quaternion
+	}{
+		{"start", IdentityQuaternion, z(90), 0, IdentityQuaternion},
+		{"end", IdentityQuaternion, z(90), 1, z(90)},
+		{"halfway", IdentityQuaternion, z(90), 0.5, z(45)},
+		{"quarter", z(20), z(100), 0.25, z(40)},
+		{"shortest arc", IdentityQuaternion, negate(z(90)), 0.5, z(45)},
+		{"across 180 degrees", z(170), z(-170), 0.5, z(180)},
+		{"nearly equal", z(10), z(10 + 1e-7), 0.5, z(10 + 0.5e-7)},
+		{"equal", z(10), z(10), 0.3, z(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slerp(tt.a, tt.b, tt.t)
+			if !sameRotation(got, tt.want) {
+				t.Errorf("rotation is %v, want %v", got, tt.want)
+			}
+			if math.Abs(got.Norm()-1) > 1e-12 {
+				t.Errorf("norm is %g, want 1", got.Norm())
+			}
+		})
+	}
+}
+
+func TestQuaternionFromHeadingPitchRoll(t *testing.T) {
+	const right = math.Pi / 2
+
+	tests := []struct {
+		name                 string
+		lon, lat             float64
+		heading, pitch, roll float64
+		x, z                 [3]float64 // the body axes in Earth-fixed coordinates
+	}{
+		{"level facing east", 0, 0, 0, 0, 0, [3]float64{0, 1, 0}, [3]float64{1, 0, 0}},
+		{"heading south", 0, 0, right, 0, 0, [3]float64{0, 0, -1}, [3]float64{1, 0, 0}},
+		{"compass heading north", 0, 0, -right, 0, 0, [3]float64{0, 0, 1}, [3]float64{1, 0, 0}},
+		{"pitched up", 0, 0, 0, right, 0, [3]float64{1, 0, 0}, [3]float64{0, -1, 0}},
+		{"rolled", 0, 0, 0, 0, right, [3]float64{0, 1, 0}, [3]float64{0, 0, -1}},
+		{"90 degrees east", right, 0, 0, 0, 0, [3]float64{-1, 0, 0}, [3]float64{0, 1, 0}},
+		{"north pole", 0, right, 0, 0, 0, [3]float64{0, 1, 0}, [3]float64{0, 0, 1}},
+		{"heading south at the north pole", 0, right, right, 0, 0, [3]float64{1, 0, 0}, [3]float64{0, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := QuaternionFromHeadingPitchRoll(tt.lon, tt.lat, tt.heading, tt.pitch, tt.roll)
+			if x := q.Rotate([3]float64{1, 0, 0}); !closeVector(x, tt.x) {
+				t.Errorf("body x axis is %v, want %v", x, tt.x)
+			}
+			if z := q.Rotate([3]float64{0, 0, 1}); !closeVector(z, tt.z) {
+				t.Errorf("body z axis is %v, want %v", z, tt.z)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package czml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrBrokenReference is returned when a reference names an entity or property that does not
+	// exist
+	ErrBrokenReference = errors.New("czml: broken reference")
+
+	// ErrCircularReference is returned when a chain of references leads back to itself
+	ErrCircularReference = errors.New("czml: circular reference")
+)
+
+// Ref returns a reference to a property of an entity, such as Ref("aircraft", "billboard", "color")
+// for "aircraft#billboard.color". Backslashes, '#' and '.' in the id and property names are escaped
+// with a backslash, as the CZML spec requires. An empty id refers to the entity holding the
+// reference.
+func Ref(id string, path ...string) ReferenceValue {
+	escaped := make([]string, len(path))
+	for i, p := range path {
+		escaped[i] = escapeReference(p)
+	}
+
+	return ReferenceValue(escapeReference(id) + "#" + strings.Join(escaped, "."))
+}
+
+// Split returns the id and property path of the reference, with escapes removed
+func (r ReferenceValue) Split() (string, []string, error) {
+	var parts []string
+	var current strings.Builder
+	id, seenHash := "", false
+
+	for i := 0; i < len(r); i++ {
+		switch c := r[i]; {
+		case c == '\\':
+			if i+1 == len(r) {
+				return "", nil, fmt.Errorf("czml: reference %q ends with an escape", string(r))
+			}
+			i++
+			current.WriteByte(r[i])
+		case c == '#' && !seenHash:
+			id, seenHash = current.String(), true
+			current.Reset()
+		case c == '.' && seenHash:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	parts = append(parts, current.String())
+
+	if !seenHash {
+		return "", nil, fmt.Errorf("czml: reference %q has no '#'", string(r))
+	}
+	for _, p := range parts {
+		if p == "" {
+			return "", nil, fmt.Errorf("czml: reference %q has an empty property name", string(r))
+		}
+	}
+
+	return id, parts, nil
+}
+
+func escapeReference(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `#`, `\#`, `.`, `\.`).Replace(s)
+}
+
+// Resolver follows references between the properties of the entities of a Czml. Entities with
+// several packets are merged first, as Cesium does.
+type Resolver struct {
+	scene *Scene
+}
+
+// NewResolver returns a Resolver for the entities of c. Packets without an id are ignored.
+func NewResolver(c Czml) *Resolver {
+	s := NewScene()
+	for _, p := range c.Packets {
+		s.Apply(p)
+	}
+
+	return &Resolver{scene: s}
+}
+
+// Resolve returns the property a reference points to, such as a *Property[Color] or a
+// *Property[Position], following chains of references until a property with a value of its own is
+// found. from is the id of the entity holding the reference, used by references with an empty id
+// such as "#position". Errors wrap ErrBrokenReference or ErrCircularReference.
+func (r *Resolver) Resolve(from string, ref ReferenceValue) (interface{}, error) {
+	visited := map[string]bool{}
+
+	for {
+		id, path, err := ref.Split()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBrokenReference, err)
+		}
+		if id == "" {
+			id = from
+		}
+
+		key := id + "#" + strings.Join(path, ".")
+		if visited[key] {
+			return nil, fmt.Errorf("%w: %q", ErrCircularReference, string(ref))
+		}
+		visited[key] = true
+
+		v, err := r.lookup(id, path)
+		if err != nil {
+			return nil, err
+		}
+
+		next, ok := referenceOf(v)
+		if !ok {
+			return v.Interface(), nil
+		}
+		ref, from = next, id
+	}
+}
+
+// lookup returns the property at a path of an entity
+func (r *Resolver) lookup(id string, path []string) (reflect.Value, error) {
+	var entity *Packet
+	if id == "document" {
+		entity = r.scene.document
+	} else {
+		entity = r.scene.entities[id]
+	}
+	if entity == nil {
+		return reflect.Value{}, fmt.Errorf("%w: entity %q does not exist", ErrBrokenReference, id)
+	}
+
+	v := reflect.ValueOf(entity)
+	for _, name := range path {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+
+		if p, ok := addressed(v).(propertyValuer); ok {
+			if value, ok := p.propertyValue(); ok {
+				v = value
+			}
+		}
+
+		var ok bool
+		switch v.Kind() {
+		case reflect.Struct:
+			v, ok = fieldByJSONName(v, name)
+		case reflect.Map:
+			v = v.MapIndex(reflect.ValueOf(name))
+			ok = v.IsValid()
+		}
+		if !ok || ((v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || v.Kind() == reflect.Map) && v.IsNil()) {
+			return reflect.Value{}, fmt.Errorf("%w: %q has no property %s", ErrBrokenReference, id, strings.Join(path, "."))
+		}
+	}
+
+	return v, nil
+}
+
+// referenceOf returns the reference held by a property that is itself a reference, either a
+// reference value, an object with its reference set, or a Property holding such an object
+func referenceOf(v reflect.Value) (ReferenceValue, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if v.Type() == referenceValueType || v.Type() == velocityReferenceValueType {
+			return ReferenceValue(v.String()), true
+		}
+	case reflect.Struct:
+		field, ok := v.Type().FieldByName("Reference")
+		if !ok || field.Type.Kind() == reflect.Ptr && v.FieldByIndex(field.Index).IsNil() {
+			return "", false
+		}
+		ref := reflect.Indirect(v.FieldByIndex(field.Index))
+		if ref.Type() == referenceValueType && ref.String() != "" {
+			return ReferenceValue(ref.String()), true
+		}
+		if p, ok := addressed(v).(propertyValuer); ok {
+			if value, ok := p.propertyValue(); ok {
+				return referenceOf(value)
+			}
+		}
+	}
+
+	return "", false
+}
+
+// addressed returns a pointer to v when it is addressable, so that methods with pointer receivers
+// can be found
+func addressed(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+
+	return nil
+}
+
+var (
+	referenceValueType         = reflect.TypeOf(ReferenceValue(""))
+	velocityReferenceValueType = reflect.TypeOf(VelocityReferenceValue(""))
+)
@@ -0,0 +1,153 @@
+package czml
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	doc := Czml{Packets: append([]Packet{{Id: "document", Version: "1.0"}}, parsePackets(t, []string{
+		`{"id": "a", "billboard": {"scale": {"reference": "b#billboard.scale"}, "color": {"reference": "b#billboard.color"}}}`,
+		`{"id": "b", "billboard": {"scale": {"reference": "c#billboard.scale"}, "color": {"reference": "c#billboard.color"}}}`,
+		`{"id": "c", "billboard": {"scale": 2, "color": {"rgba": [1, 2, 3, 4]}}}`,
+		`{"id": "e", "billboard": {"scale": {"reference": "f#billboard.scale"}}}`,
+		`{"id": "f", "billboard": {"scale": {"reference": "e#billboard.scale"}}}`,
+		`{"id": "g", "billboard": {"scale": {"reference": "missing#billboard.scale"}}}`,
+		`{"id": "h", "position": {"reference": "#position"}}`,
+	})...)}
+	resolver := NewResolver(doc)
+
+	tests := []struct {
+		name string
+		from string
+		ref  ReferenceValue
+		want string // the resolved property written as JSON
+		err  error
+	}{
+		{"number", "a", "b#billboard.scale", `{"number":2}`, nil},
+		{"chain of numbers", "a", "a#billboard.scale", `{"number":2}`, nil},
+		{"chain of colors", "a", "a#billboard.color", `{"rgba":[1,2,3,4]}`, nil},
+		{"value within a property", "a", "c#billboard.color.rgba", `[1,2,3,4]`, nil},
+		{"circular numbers", "e", "e#billboard.scale", "", ErrCircularReference},
+		{"broken chain", "g", "g#billboard.scale", "", ErrBrokenReference},
+		{"own property", "h", "#position", "", ErrCircularReference},
+		{"missing property", "c", "c#label.text", "", ErrBrokenReference},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(tt.from, tt.ref)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("error is %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := packetJSON(t, got); s != tt.want {
+				t.Errorf("resolved to %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	doc := Czml{Packets: append([]Packet{{Id: "document", Version: "1.0"}}, parsePackets(t, []string{
+		`{"id": "e", "billboard": {"scale": {"reference": "f#billboard.scale"}}}`,
+		`{"id": "f", "billboard": {"scale": {"reference": "e#billboard.scale"}}}`,
+		`{"id": "g", "label": {"outlineWidth": {"reference": "missing#label.outlineWidth"}}}`,
+	})...)}
+
+	want := []string{
+		"packets[1].billboard.scale.reference: czml: circular reference",
+		"packets[2].billboard.scale.reference: czml: circular reference",
+		"packets[3].label.outlineWidth.reference: czml: broken reference",
+	}
+	errs := doc.Validate()
+	if len(errs) != len(want) {
+		t.Fatalf("found %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, e := range errs {
+		if !strings.HasPrefix(e.Error(), want[i]) {
+			t.Errorf("error %d is %q, want it to start with %q", i, e.Error(), want[i])
+		}
+	}
+}
+
+func TestRef(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		path []string
+		want ReferenceValue
+	}{
+		{"plain", "aircraft", []string{"billboard", "color"}, `aircraft#billboard.color`},
+		{"own entity", "", []string{"position"}, `#position`},
+		{"dot in the id", "a.b", []string{"position"}, `a\.b#position`},
+		{"hash in the id", "a#b", []string{"position"}, `a\#b#position`},
+		{"backslash in the id", `a\b`, []string{"position"}, `a\\b#position`},
+		{"dot in a property name", "e", []string{"properties", "x.y"}, `e#properties.x\.y`},
+		{"hash in a property name", "e", []string{"properties", "#1"}, `e#properties.\#1`},
+		{"escaped backslash before a dot", `a\`, []string{"b.", "c"}, `a\\#b\..c`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Ref(tt.id, tt.path...)
+			if got != tt.want {
+				t.Errorf("reference is %s, want %s", got, tt.want)
+			}
+
+			// Split undoes the escaping
+			id, path, err := got.Split()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.id || !reflect.DeepEqual(path, tt.path) {
+				t.Errorf("split into %q %q, want %q %q", id, path, tt.id, tt.path)
+			}
+		})
+	}
+}
+
+func TestReferenceSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  ReferenceValue
+		id   string
+		path []string
+		err  string
+	}{
+		{"plain", `e#billboard.color`, "e", []string{"billboard", "color"}, ""},
+		{"second hash is part of the path", `e#properties.a#b`, "e", []string{"properties", "a#b"}, ""},
+		{"dot before the hash is part of the id", `a.b#position`, "a.b", []string{"position"}, ""},
+		{"escaped character", `a\xb#position`, "axb", []string{"position"}, ""},
+		{"no hash", `e.position`, "", nil, "has no '#'"},
+		{"escaped hash only", `e\#position`, "", nil, "has no '#'"},
+		{"trailing escape", `e#position\`, "", nil, "ends with an escape"},
+		{"empty path", `e#`, "", nil, "empty property name"},
+		{"empty property name", `e#billboard..color`, "", nil, "empty property name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, path, err := tt.ref.Split()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error is %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != tt.id || !reflect.DeepEqual(path, tt.path) {
+				t.Errorf("split into %q %q, want %q %q", id, path, tt.id, tt.path)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeTag is the time of a sample, given either as a date or as a number of seconds since the epoch
+// of the property the sample belongs to
+type TimeTag struct {
+	Date    JulianDate
+	Seconds float64
+}
+
+// DateTag returns a TimeTag for a date
+func DateTag(date time.Time) TimeTag {
+	return TimeTag{Date: NewJulianDate(date)}
+}
+
+// SecondsTag returns a TimeTag for a number of seconds since the epoch of the property
+func SecondsTag(seconds float64) TimeTag {
+	return TimeTag{Seconds: seconds}
+}
+
+// IsDate reports whether the tag is an ISO 8601 date rather than seconds since epoch
+func (t TimeTag) IsDate() bool {
+	return !t.Date.IsZero()
+}
+
+// MarshalJSON encodes the tag as a string for dates and as a number for seconds since epoch
+func (t TimeTag) MarshalJSON() ([]byte, error) {
+	if t.IsDate() {
+		return json.Marshal(t.Date)
+	}
+
+	return json.Marshal(t.Seconds)
+}
+
+// UnmarshalJSON decodes a tag from either a string or a number
+func (t *TimeTag) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	return t.set(v)
+}
+
+func (t *TimeTag) set(v interface{}) error {
+	switch v := v.(type) {
+	case string:
+		date, err := ParseJulianDate(v)
+		if err != nil {
+			return err
+		}
+		*t = TimeTag{Date: date}
+	case float64:
+		*t = TimeTag{Seconds: v}
+	default:
+		return fmt.Errorf("time tag must be a string or a number, found %v", v)
+	}
+
+	return nil
+}
+
+// Sample is a value tagged with the time at which it applies
+type Sample struct {
+	Time  TimeTag
+	Value []float64
+}
+
+// SampledValue is the contents of every value type that can vary over time. It holds either a
+// constant Value or a list of time-tagged Samples. In JSON, a constant is written as
+// [X, Y, ...] and samples are written as [Time, X, Y, ..., Time, X, Y, ...].
+type SampledValue struct {
+	Value   []float64
+	Samples []Sample
+}
+
+// IsSampled reports whether the value is a list of time-tagged samples rather than a constant
+func (v SampledValue) IsSampled() bool {
+	return len(v.Samples) > 0
+}
+
+// AddSample appends a time-tagged sample to the value
+func (v *SampledValue) AddSample(t TimeTag, value ...float64) {
+	v.Samples = append(v.Samples, Sample{Time: t, Value: value})
+}
+
+// values returns the SampledValue embedded in a value type
+func (v SampledValue) values() SampledValue {
+	return v
+}
+
+// sampled returns a pointer to the SampledValue embedded in a value type
+func (v *SampledValue) sampled() *SampledValue {
+	return v
+}
+
+// MarshalJSON encodes the value as a flat array, with times as strings or numbers and every
+// coordinate as a number
+func (v SampledValue) MarshalJSON() ([]byte, error) {
+	if !v.IsSampled() {
+		if v.Value == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(v.Value)
+	}
+
+	flat := make([]interface{}, 0, len(v.Samples)*(len(v.Samples[0].Value)+1))
+	for _, s := range v.Samples {
+		flat = append(flat, s.Time)
+		for _, c := range s.Value {
+			flat = append(flat, c)
+		}
+	}
+
+	return json.Marshal(flat)
+}
+
+// decode reads a constant or time-tagged value of the provided dimension from a flat JSON array
+func (v *SampledValue) decode(data []byte, dimension int) error {
+	var flat []interface{}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return err
+	}
+
+	*v = SampledValue{}
+	if len(flat) == 0 {
+		return nil
+	}
+
+	if len(flat) == dimension {
+		value, err := toFloats(flat)
+		if err != nil {
+			return err
+		}
+		v.Value = value
+		return nil
+	}
+
+	if len(flat)%(dimension+1) != 0 {
+		return fmt.Errorf("czml: length %d is not %d or a multiple of %d", len(flat), dimension, dimension+1)
+	}
+
+	v.Samples = make([]Sample, 0, len(flat)/(dimension+1))
+	for i := 0; i < len(flat); i += dimension + 1 {
+		var s Sample
+		if err := s.Time.set(flat[i]); err != nil {
+			return fmt.Errorf("czml: %v", err)
+		}
+
+		value, err := toFloats(flat[i+1 : i+1+dimension])
+		if err != nil {
+			return err
+		}
+		s.Value = value
+
+		v.Samples = append(v.Samples, s)
+	}
+
+	return nil
+}
+
+// check verifies that the value and every sample have the provided dimension
+func (v SampledValue) check(dimension int) string {
+	if !v.IsSampled() {
+		if len(v.Value) != dimension {
+			return fmt.Sprintf("value has %d components, not %d", len(v.Value), dimension)
+		}
+		return ""
+	}
+
+	for i, s := range v.Samples {
+		if len(s.Value) != dimension {
+			return fmt.Sprintf("sample %d has %d components, not %d", i, len(s.Value), dimension)
+		}
+	}
+
+	return ""
+}
+
+func toFloats(values []interface{}) ([]float64, error) {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, errors.New("czml: expected a number in value, found " + fmt.Sprint(v))
+		}
+		result[i] = f
+	}
+
+	return result, nil
+}
+
+// sampledType is implemented by every value type that embeds a SampledValue
+type sampledType interface {
+	values() SampledValue
+	dimension() int
+}
+
+func (Cartesian2Value) dimension() int                   { return 2 }
+func (Cartesian3Value) dimension() int                   { return 3 }
+func (Cartesian3VelocityValue) dimension() int           { return 6 }
+func (UnitCartesian3Value) dimension() int               { return 3 }
+func (CartographicDegreesValue) dimension() int          { return 3 }
+func (CartographicRadiansValue) dimension() int          { return 3 }
+func (CartographicRectangleRadiansValue) dimension() int { return 4 }
+func (CartographicRectangleDegreesValue) dimension() int { return 4 }
+func (RgbaValue) dimension() int                         { return 4 }
+func (RgbafValue) dimension() int                        { return 4 }
+func (UnitSphericalValue) dimension() int                { return 2 }
+func (SphericalValue) dimension() int                    { return 3 }
+func (UnitQuaternionValue) dimension() int               { return 4 }
+func (NearFarScalarValue) dimension() int                { return 4 }
+func (BoundingRectangleValue) dimension() int            { return 4 }
+func (DistanceDisplayConditionValue) dimension() int     { return 2 }
+func (DoubleValue) dimension() int                       { return 1 }
+
+func (v *Cartesian2Value) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *Cartesian3Value) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *Cartesian3VelocityValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *UnitCartesian3Value) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *CartographicDegreesValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *CartographicRadiansValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *CartographicRectangleRadiansValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *CartographicRectangleDegreesValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *RgbaValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *RgbafValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *UnitSphericalValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *SphericalValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *UnitQuaternionValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *NearFarScalarValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *BoundingRectangleValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+func (v *DistanceDisplayConditionValue) UnmarshalJSON(data []byte) error {
+	return v.decode(data, v.dimension())
+}
+
+// MarshalJSON writes a constant as a single number, and samples as a flat array
+func (v DoubleValue) MarshalJSON() ([]byte, error) {
+	if !v.IsSampled() && len(v.Value) == 1 {
+		return json.Marshal(v.Value[0])
+	}
+
+	return v.SampledValue.MarshalJSON()
+}
+
+// UnmarshalJSON reads a constant given as a single number, or a flat array
+func (v *DoubleValue) UnmarshalJSON(data []byte) error {
+	var number float64
+	if json.Unmarshal(data, &number) == nil {
+		*v = DoubleValue{SampledValue{Value: []float64{number}}}
+		return nil
+	}
+
+	return v.decode(data, v.dimension())
+}
@@ -0,0 +1,118 @@
+package czml
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSampledValueJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		samples int
+		want    string // the value written back, when it differs from data
+	}{
+		{"constant", `[1,2,3]`, 0, ""},
+		{"empty", `[]`, 0, ""},
+		{"dates", `["2020-01-01T00:00:00Z",1,2,3,"2020-01-01T00:01:00Z",4,5,6]`, 2, ""},
+		{"seconds since epoch", `[0,1,2,3,60,4,5,6]`, 2, ""},
+		{"dates and seconds", `["2020-01-01T00:00:00Z",1,2,3,60,4,5,6]`, 2, ""},
+		{"date in another form", `["20200101T000000Z",1,2,3]`, 1, `["2020-01-01T00:00:00Z",1,2,3]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Cartesian3Value
+			if err := json.Unmarshal([]byte(tt.data), &v); err != nil {
+				t.Fatal(err)
+			}
+			if len(v.Samples) != tt.samples {
+				t.Errorf("read %d samples, want %d", len(v.Samples), tt.samples)
+			}
+
+			got, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if want == "" {
+				want = tt.data
+			}
+			if string(got) != want {
+				t.Errorf("written as %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestSampledValueErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"length", `[1,2,3,4,5]`, "length 5 is not 3 or a multiple of 4"},
+		{"coordinate as a string", `["2020-01-01T00:00:00Z","1",2,3]`, "expected a number in value"},
+		{"time as a boolean", `[true,1,2,3]`, "time tag must be a string or a number"},
+		{"time that is not a date", `["yesterday",1,2,3]`, "is not an ISO 8601 date and time"},
+		{"not an array", `{"x":1}`, "cannot unmarshal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Cartesian3Value
+			err := json.Unmarshal([]byte(tt.data), &v)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error is %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoubleValueJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"constant", `2.5`},
+		{"samples", `["2020-01-01T00:00:00Z",1,60,2]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v DoubleValue
+			if err := json.Unmarshal([]byte(tt.data), &v); err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.data {
+				t.Errorf("written as %s, want %s", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestSampledValueCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		value SampledValue
+		want  string
+	}{
+		{"constant", SampledValue{Value: []float64{1, 2, 3}}, ""},
+		{"short constant", SampledValue{Value: []float64{1, 2}}, "value has 2 components, not 3"},
+		{"samples", SampledValue{Samples: []Sample{{SecondsTag(0), []float64{1, 2, 3}}, {SecondsTag(60), []float64{4, 5, 6}}}}, ""},
+		{"short sample", SampledValue{Samples: []Sample{{SecondsTag(0), []float64{1, 2, 3}}, {SecondsTag(60), []float64{4, 5, 6, 7}}}}, "sample 1 has 4 components, not 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.check(3); got != tt.want {
+				t.Errorf("check is %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
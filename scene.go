@@ -0,0 +1,466 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// Scene is the state of a CZML stream after its packets have been applied in order. Later packets
+// with the id of an earlier one update it property by property, the same way Cesium's
+// CzmlDataSource processes them:
+//   - constant values replace earlier values
+//   - interval lists are added to earlier interval lists, with later intervals taking precedence
+//   - samples are added to earlier samples of the same property, in time order
+//   - a packet with "delete": true removes its entity
+type Scene struct {
+	document *Packet
+	entities map[string]*Packet
+	order    []string
+}
+
+// NewScene returns an empty Scene
+func NewScene() *Scene {
+	return &Scene{entities: map[string]*Packet{}}
+}
+
+// Apply updates the scene with a packet
+func (s *Scene) Apply(p Packet) error {
+	if p.Id == "" {
+		return errors.New("czml: packet has no id")
+	}
+
+	p = deepCopy(reflect.ValueOf(p)).Interface().(Packet)
+
+	if p.Id == "document" {
+		if p.Delete != nil && *p.Delete {
+			return errors.New("czml: the document packet cannot be deleted")
+		}
+		if s.document == nil {
+			s.document = &p
+			return nil
+		}
+		merge(reflect.ValueOf(s.document).Elem(), reflect.ValueOf(&p).Elem(), nil)
+		return nil
+	}
+
+	if p.Delete != nil && *p.Delete {
+		s.remove(p.Id)
+		return nil
+	}
+
+	existing, ok := s.entities[p.Id]
+	if !ok {
+		s.entities[p.Id] = &p
+		s.order = append(s.order, p.Id)
+		return nil
+	}
+
+	merge(reflect.ValueOf(existing).Elem(), reflect.ValueOf(&p).Elem(), nil)
+	return nil
+}
+
+// Load applies every packet of a document in order
+func (s *Scene) Load(c Czml) error {
+	for _, p := range c.Packets {
+		if err := s.Apply(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Document returns the document packet, or false if none has been applied
+func (s *Scene) Document() (Packet, bool) {
+	if s.document == nil {
+		return Packet{}, false
+	}
+
+	return deepCopy(reflect.ValueOf(*s.document)).Interface().(Packet), true
+}
+
+// Entity returns the current state of an entity, or false if the scene does not hold it
+func (s *Scene) Entity(id string) (Packet, bool) {
+	p, ok := s.entities[id]
+	if !ok {
+		return Packet{}, false
+	}
+
+	return deepCopy(reflect.ValueOf(*p)).Interface().(Packet), true
+}
+
+// Entities returns the ids of the entities in the scene, in the order they were first applied
+func (s *Scene) Entities() []string {
+	return append([]string(nil), s.order...)
+}
+
+// Snapshot returns the scene as a document holding one packet per entity. If no document packet
+// has been applied, a default one is added.
+func (s *Scene) Snapshot() Czml {
+	c := Czml{Packets: make([]Packet, 0, len(s.order)+1)}
+
+	if document, ok := s.Document(); ok {
+		c.AddPacket(document)
+	} else {
+		c.InitializeDocument("")
+	}
+
+	c.Packets = append(c.Packets, s.entityPackets()...)
+	return c
+}
+
+// Compact returns c with the packets of every entity merged, in order, into a single packet per
+// entity. Samples are combined in time order, with later samples replacing earlier ones at the same
+// time, the last constant value of each property is kept, and entities that end deleted are
+// dropped. Packets without an id are kept as they are, after the others. Packets that cannot be
+// applied, such as one deleting the document, are skipped.
+func Compact(c Czml) Czml {
+	return compact(c, func(Packet, error) {})
+}
+
+// compact merges the packets of c as Compact does, calling failed for every packet that cannot be
+// applied and leaving it out
+func compact(c Czml, failed func(Packet, error)) Czml {
+	s := NewScene()
+	var anonymous []Packet
+	for _, p := range c.Packets {
+		if p.Id == "" {
+			anonymous = append(anonymous, p)
+			continue
+		}
+		if err := s.Apply(p); err != nil {
+			failed(p, err)
+		}
+	}
+
+	compacted := Czml{}
+	if document, ok := s.Document(); ok {
+		compacted.AddPacket(document)
+	}
+	compacted.Packets = append(compacted.Packets, s.entityPackets()...)
+	compacted.Packets = append(compacted.Packets, anonymous...)
+
+	return compacted
+}
+
+// entityPackets returns a copy of every entity in the scene, in the order they were first applied
+func (s *Scene) entityPackets() []Packet {
+	packets := make([]Packet, 0, len(s.order))
+	for _, id := range s.order {
+		p, _ := s.Entity(id)
+		packets = append(packets, p)
+	}
+
+	return packets
+}
+
+func (s *Scene) remove(id string) {
+	if _, ok := s.entities[id]; !ok {
+		return
+	}
+
+	delete(s.entities, id)
+	for i, o := range s.order {
+		if o == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// merger is implemented by values with their own rules for applying a later value
+type merger interface {
+	merge(src interface{})
+}
+
+// sampledValuer is implemented by pointers to every value type embedding a SampledValue
+type sampledValuer interface {
+	sampled() *SampledValue
+}
+
+var (
+	interpolatableType = reflect.TypeOf(Interpolatable{})
+	marshalerType      = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	sampledValuerType  = reflect.TypeOf((*sampledValuer)(nil)).Elem()
+)
+
+// merge applies src onto dst. Values that are not set in src leave dst unchanged. src must not be
+// used afterwards, as parts of it may be moved into dst. epoch is the epoch of the samples in dst.
+func merge(dst, src reflect.Value, epoch *JulianDate) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		merge(dst.Elem(), src.Elem(), epoch)
+	case reflect.Struct:
+		mergeStruct(dst, src, epoch)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+func mergeStruct(dst, src reflect.Value, epoch *JulianDate) {
+	switch d := dst.Addr().Interface().(type) {
+	case merger:
+		d.merge(src.Addr().Interface())
+		return
+	case sampledValuer:
+		d.sampled().merge(src.Addr().Interface().(sampledValuer).sampled(), epoch)
+		return
+	}
+
+	if reflect.PtrTo(dst.Type()).Implements(marshalerType) {
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+		return
+	}
+
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Anonymous && t.Field(i).Type == interpolatableType {
+			epoch = mergeEpoch(dst, src, i)
+			break
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			merge(dst.Field(i), src.Field(i), epoch)
+		}
+	}
+}
+
+// mergeEpoch prepares src for merging into dst, where field i of both is their Interpolatable,
+// and returns the epoch of dst afterwards. A Value given in a different representation than dst,
+// such as cartographicDegrees after cartesian, replaces it. When the epochs differ and dst has
+// samples relative to its epoch, samples in src given in seconds are converted to dates.
+func mergeEpoch(dst, src reflect.Value, i int) *JulianDate {
+	di := dst.Field(i).Addr().Interface().(*Interpolatable)
+	si := src.Field(i).Addr().Interface().(*Interpolatable)
+
+	if representations(src) > 0 {
+		t := dst.Type()
+		for j := 0; j < t.NumField(); j++ {
+			if isRepresentation(t.Field(j)) && src.Field(j).IsZero() {
+				dst.Field(j).Set(reflect.Zero(t.Field(j).Type))
+			}
+		}
+	}
+
+	if si.Epoch != nil && !sameEpoch(di.Epoch, si.Epoch) {
+		if usesEpoch(dst) {
+			for _, v := range sampledFields(src) {
+				v.resolveTimes(si.Epoch)
+			}
+		} else {
+			di.Epoch = si.Epoch
+		}
+	}
+	si.Epoch = nil
+
+	return di.Epoch
+}
+
+// isRepresentation reports whether a field holds one of the alternative forms of a value
+func isRepresentation(f reflect.StructField) bool {
+	return f.Name == "Reference" || f.Name == "VelocityReference" ||
+		(f.Type.Kind() == reflect.Ptr && f.Type.Implements(sampledValuerType))
+}
+
+// representations returns the number of alternative forms of a value set in v
+func representations(v reflect.Value) int {
+	n := 0
+	for i := 0; i < v.NumField(); i++ {
+		if isRepresentation(v.Type().Field(i)) && !v.Field(i).IsZero() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// sampledFields returns the sampled values set in the fields of a struct
+func sampledFields(v reflect.Value) []*SampledValue {
+	var result []*SampledValue
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		if s, ok := v.Field(i).Interface().(sampledValuer); ok && !v.Field(i).IsNil() {
+			result = append(result, s.sampled())
+		}
+	}
+
+	return result
+}
+
+// usesEpoch reports whether any sampled value set in the fields of a struct has samples given in
+// seconds since epoch
+func usesEpoch(v reflect.Value) bool {
+	for _, s := range sampledFields(v) {
+		for _, sample := range s.Samples {
+			if !sample.Time.IsDate() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func sameEpoch(a, b *JulianDate) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(b.Time)
+}
+
+// merge adds the samples of src to v in time order, replacing samples of v at the same times. A
+// constant in src, or samples in src replacing a constant, replace v entirely. Samples whose times
+// cannot be resolved against epoch are appended in the order given.
+func (v *SampledValue) merge(src *SampledValue, epoch *JulianDate) {
+	if !src.IsSampled() {
+		if src.Value != nil {
+			*v = *src
+		}
+		return
+	}
+	if !v.IsSampled() {
+		*v = *src
+		return
+	}
+
+	samples := append(v.Samples, src.Samples...)
+	times := make([]JulianDate, len(samples))
+	for i, s := range samples {
+		t, err := s.Time.Resolve(epoch)
+		if err != nil {
+			v.Samples = samples
+			return
+		}
+		times[i] = NewJulianDate(t)
+	}
+
+	order := make([]int, len(samples))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return times[order[a]].Before(times[order[b]].Time) })
+
+	v.Samples = make([]Sample, 0, len(samples))
+	for i, o := range order {
+		if i+1 < len(order) && times[order[i+1]].Equal(times[o].Time) {
+			continue
+		}
+		v.Samples = append(v.Samples, samples[o])
+	}
+}
+
+// resolveTimes converts sample times given in seconds since epoch to dates
+func (v *SampledValue) resolveTimes(epoch *JulianDate) {
+	for i, s := range v.Samples {
+		if t, err := s.Time.Resolve(epoch); err == nil {
+			v.Samples[i].Time = DateTag(t)
+		}
+	}
+}
+
+// merge applies a later value of the property. A single value replaces the property, or is merged
+// into a single value held in an object such as a Double, so that samples add to earlier samples.
+// Intervals are added to earlier intervals, replacing those with the same interval.
+func (p *Property[T]) merge(src interface{}) {
+	s := src.(*Property[T])
+	if value := reflect.ValueOf(&p.Value).Elem(); value.Kind() == reflect.Struct &&
+		!s.HasIntervals() && !p.HasIntervals() && s.Reference == "" && p.Reference == "" {
+		merge(value, reflect.ValueOf(&s.Value).Elem(), nil)
+		return
+	}
+	if !s.HasIntervals() || !p.HasIntervals() {
+		*p = *s
+		return
+	}
+
+	for _, iv := range s.Intervals {
+		kept := p.Intervals[:0]
+		for _, existing := range p.Intervals {
+			if !existing.Interval.Start.Equal(iv.Interval.Start.Time) ||
+				!existing.Interval.Stop.Equal(iv.Interval.Stop.Time) {
+				kept = append(kept, existing)
+			}
+		}
+		p.Intervals = append(kept, iv)
+	}
+}
+
+// deepCopy returns a copy of v that shares no pointers, slices or maps with it
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	}
+
+	return v
+}
@@ -0,0 +1,128 @@
+package czml
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// parsePackets reads packets written as JSON objects
+func parsePackets(t *testing.T, packets []string) []Packet {
+	t.Helper()
+
+	result := make([]Packet, len(packets))
+	for i, p := range packets {
+		if err := json.Unmarshal([]byte(p), &result[i]); err != nil {
+			t.Fatalf("packet %d: %v", i, err)
+		}
+	}
+
+	return result
+}
+
+// packetJSON returns a packet written as JSON, or the JSON of a packet written some other way
+func packetJSON(t *testing.T, p interface{}) string {
+	t.Helper()
+
+	if s, ok := p.(string); ok {
+		var packet Packet
+		if err := json.Unmarshal([]byte(s), &packet); err != nil {
+			t.Fatal(err)
+		}
+		p = packet
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestSceneApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		packets []string
+		want    string // the entity "e" afterwards, or empty if it does not exist
+	}{
+		{"constants replace earlier values", []string{
+			`{"id": "e", "name": "first", "label": {"text": "a", "scale": 2}}`,
+			`{"id": "e", "label": {"text": "b"}}`,
+		}, `{"id": "e", "name": "first", "label": {"text": "b", "scale": 2}}`},
+		{"samples are merged in time order", []string{
+			`{"id": "e", "position": {"cartographicDegrees": ["2020-01-01T00:00:00Z", 1, 1, 0, "2020-01-01T00:02:00Z", 3, 3, 0]}}`,
+			`{"id": "e", "position": {"cartographicDegrees": ["2020-01-01T00:01:00Z", 2, 2, 0, "2020-01-01T00:02:00Z", 4, 4, 0]}}`,
+		}, `{"id": "e", "position": {"cartographicDegrees": ["2020-01-01T00:00:00Z", 1, 1, 0, "2020-01-01T00:01:00Z", 2, 2, 0, "2020-01-01T00:02:00Z", 4, 4, 0]}}`},
+		{"samples of doubles are merged", []string{
+			`{"id": "e", "path": {"width": {"number": ["2020-01-01T00:00:00Z", 1]}}}`,
+			`{"id": "e", "path": {"width": {"number": ["2020-01-01T00:01:00Z", 2], "interpolationAlgorithm": "LAGRANGE"}}}`,
+		}, `{"id": "e", "path": {"width": {"interpolationAlgorithm": "LAGRANGE", "number": ["2020-01-01T00:00:00Z", 1, "2020-01-01T00:01:00Z", 2]}}}`},
+		{"samples relative to another epoch", []string{
+			`{"id": "e", "position": {"epoch": "2020-01-01T00:00:00Z", "cartesian": [0, 1, 2, 3]}}`,
+			`{"id": "e", "position": {"epoch": "2020-01-01T01:00:00Z", "cartesian": [0, 4, 5, 6]}}`,
+		}, `{"id": "e", "position": {"epoch": "2020-01-01T00:00:00Z", "cartesian": [0, 1, 2, 3, "2020-01-01T01:00:00Z", 4, 5, 6]}}`},
+		{"a constant replaces samples", []string{
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3]}}`,
+			`{"id": "e", "position": {"cartesian": [4, 5, 6]}}`,
+		}, `{"id": "e", "position": {"cartesian": [4, 5, 6]}}`},
+		{"another representation replaces the value", []string{
+			`{"id": "e", "position": {"cartesian": [1, 2, 3]}}`,
+			`{"id": "e", "position": {"cartographicDegrees": [4, 5, 6]}}`,
+		}, `{"id": "e", "position": {"cartographicDegrees": [4, 5, 6]}}`},
+		{"intervals are added, replacing the same interval", []string{
+			`{"id": "e", "billboard": {"show": [{"interval": "2020-01-01T00:00:00Z/2020-01-02T00:00:00Z", "boolean": true}]}}`,
+			`{"id": "e", "billboard": {"show": [{"interval": "2020-01-02T00:00:00Z/2020-01-03T00:00:00Z", "boolean": false}]}}`,
+			`{"id": "e", "billboard": {"show": [{"interval": "2020-01-01T00:00:00Z/2020-01-02T00:00:00Z", "boolean": false}]}}`,
+		}, `{"id": "e", "billboard": {"show": [
+			{"interval": "2020-01-02T00:00:00Z/2020-01-03T00:00:00Z", "boolean": false},
+			{"interval": "2020-01-01T00:00:00Z/2020-01-02T00:00:00Z", "boolean": false}]}}`},
+		{"custom properties are merged by name", []string{
+			`{"id": "e", "properties": {"a": 1, "b": 2}}`,
+			`{"id": "e", "properties": {"b": 3}}`,
+		}, `{"id": "e", "properties": {"a": 1, "b": 3}}`},
+		{"delete removes the entity", []string{
+			`{"id": "e", "name": "gone"}`,
+			`{"id": "e", "delete": true}`,
+		}, ``},
+		{"an entity created after a delete starts again", []string{
+			`{"id": "e", "name": "gone", "description": "old"}`,
+			`{"id": "e", "delete": true}`,
+			`{"id": "e", "name": "back"}`,
+		}, `{"id": "e", "name": "back"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScene()
+			for _, p := range parsePackets(t, tt.packets) {
+				if err := s.Apply(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, ok := s.Entity("e")
+			if tt.want == "" {
+				if ok {
+					t.Errorf("entity exists: %s", packetJSON(t, got))
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("entity does not exist")
+			}
+			if got, want := packetJSON(t, got), packetJSON(t, tt.want); got != want {
+				t.Errorf("entity is\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestSceneRejectsDeletingTheDocument(t *testing.T) {
+	deleted := true
+	if err := NewScene().Apply(Packet{Id: "document", Delete: &deleted}); err == nil {
+		t.Error("expected an error")
+	}
+	compacted := Compact(Czml{Packets: []Packet{{Id: "document", Version: "1.0"}, {Id: "document", Delete: &deleted}}})
+	if len(compacted.Packets) != 1 || compacted.Packets[0].Id != "document" {
+		t.Errorf("expected Compact to skip the packet, got %v", compacted.Packets)
+	}
+}
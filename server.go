@@ -0,0 +1,200 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultHeartbeat is the interval at which a Server sends heartbeat comments to idle clients
+const DefaultHeartbeat = 15 * time.Second
+
+// clientBuffer is the number of events a client may fall behind before it is disconnected
+const clientBuffer = 256
+
+// Server is an http.Handler that streams packets to Cesium clients as Server-Sent Events, one
+// packet per event. Every new client first receives the "document" packet, followed by one packet
+// per entity holding everything published for it so far, merged as a Scene does, and then every
+// packet published while it is connected.
+type Server struct {
+	// Heartbeat is the interval between heartbeat comments. Zero disables heartbeats.
+	Heartbeat time.Duration
+
+	mu      sync.Mutex
+	scene   *Scene
+	clients map[chan []byte]struct{}
+	closed  bool
+}
+
+// NewServer returns a Server that sends the provided "document" packet to each new client
+func NewServer(document Packet) (*Server, error) {
+	if document.Id != "document" {
+		return nil, errors.New("first packet must be the document packet")
+	}
+
+	scene := NewScene()
+	if err := scene.Apply(document); err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		Heartbeat: DefaultHeartbeat,
+		scene:     scene,
+		clients:   map[chan []byte]struct{}{},
+	}, nil
+}
+
+// Publish sends a Packet to every connected client and merges it into the state replayed to new
+// clients. Publishing a deleted packet removes the entity from that state. Packets without an id
+// are sent but not replayed.
+func (s *Server) Publish(p Packet) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("server is closed")
+	}
+
+	if p.Id != "" {
+		if err := s.scene.Apply(p); err != nil {
+			return err
+		}
+	}
+
+	for c := range s.clients {
+		select {
+		case c <- data:
+		default:
+			// the client is not keeping up, so disconnect it rather than block every publisher
+			delete(s.clients, c)
+			close(c)
+		}
+	}
+
+	return nil
+}
+
+// PublishFrom publishes every Packet received from packets until the channel is closed
+func (s *Server) PublishFrom(packets <-chan Packet) error {
+	for p := range packets {
+		if err := s.Publish(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close disconnects every client. Packets can no longer be published once the Server is closed.
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for c := range s.clients {
+		delete(s.clients, c)
+		close(c)
+	}
+}
+
+// ServeHTTP streams packets to a single client until it disconnects or the Server is closed
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, replay, err := s.subscribe()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer s.unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, data := range replay {
+		if err := writeEvent(w, data); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	var heartbeat <-chan time.Time
+	if s.Heartbeat > 0 {
+		ticker := time.NewTicker(s.Heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, data); err != nil {
+				return
+			}
+		case <-heartbeat:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// subscribe registers a new client and returns the events needed to bring it up to date
+func (s *Server) subscribe() (chan []byte, [][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, nil, errors.New("server is closed")
+	}
+
+	snapshot := s.scene.Snapshot()
+	replay := make([][]byte, 0, len(snapshot.Packets))
+	for _, p := range snapshot.Packets {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		replay = append(replay, data)
+	}
+
+	c := make(chan []byte, clientBuffer)
+	s.clients[c] = struct{}{}
+
+	return c, replay, nil
+}
+
+func (s *Server) unsubscribe(c chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[c]; ok {
+		delete(s.clients, c)
+		close(c)
+	}
+}
+
+func writeEvent(w http.ResponseWriter, data []byte) error {
+	_, err := fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
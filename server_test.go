@@ -0,0 +1,90 @@
+package czml
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readEvents reads n events from a Server-Sent Events stream
+func readEvents(t *testing.T, r *bufio.Reader, n int) []Packet {
+	t.Helper()
+
+	var packets []Packet
+	for len(packets) < n {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading event %d: %v", len(packets), err)
+		}
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		var p Packet
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &p); err != nil {
+			t.Fatalf("event %d: %v", len(packets), err)
+		}
+		packets = append(packets, p)
+	}
+
+	return packets
+}
+
+func TestServerReplaysMergedState(t *testing.T) {
+	document := CreateEmptyPacket("document", "live")
+	document.Version = "1.0"
+	s, err := NewServer(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := Packet{Id: "p1"}
+	first.AddPosition(start, 10, 20, 100)
+	first.AddPosition(start.Add(time.Minute), 11, 21, 200)
+	second := Packet{Id: "p1", Label: &Label{Text: NewProperty("aircraft")}}
+	deleted := true
+	for _, p := range []Packet{first, second, {Id: "gone"}, {Id: "gone", Delete: &deleted}} {
+		if err := s.Publish(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ids := s.scene.Entities(); len(ids) != 1 || ids[0] != "p1" {
+		t.Errorf("replayed entities are %v, want [p1]", ids)
+	}
+
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	replay := readEvents(t, bufio.NewReader(resp.Body), 2)
+	if replay[0].Id != "document" || replay[0].Name != "live" {
+		t.Errorf("first event is %q, want the document", replay[0].Id)
+	}
+
+	entity := replay[1]
+	if entity.Id != "p1" {
+		t.Fatalf("second event is %q, want p1", entity.Id)
+	}
+	if entity.Label == nil || entity.Label.Text == nil || entity.Label.Text.Value != "aircraft" {
+		t.Errorf("label was not replayed: %+v", entity.Label)
+	}
+	if entity.Position == nil || entity.Position.Value.CartographicDegrees == nil ||
+		len(entity.Position.Value.CartographicDegrees.Samples) != 2 {
+		t.Errorf("position samples were not replayed: %+v", entity.Position)
+	}
+}
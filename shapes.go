@@ -3,6 +3,7 @@ package czml
 // BoundingRectangle holds a bounding rectangle specified by a corner, width and height.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/BoundingRectangle
 type BoundingRectangle struct {
+	Interpolatable
 	BoundingRectangle *BoundingRectangleValue `json:"boundingRectangle,omitempty"`
 	Reference         ReferenceValue          `json:"reference,omitempty"`
 }
@@ -13,26 +14,29 @@ type BoundingRectangle struct {
 // [Time, X, Y, Width, Height, Time, X, Y, Width, Height, ...], where Time is an ISO 8601 date and
 // time string or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/BoundingRectangleValue
-type BoundingRectangleValue []interface{}
+type BoundingRectangleValue struct {
+	SampledValue
+}
 
 // Box is a closed rectangular cuboid
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Box
 type Box struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Dimensions               *BoxDimensions            `json:"dimensions"`
-	HeightReference          *HeightReference          `json:"heightReference"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Dimensions               *Property[BoxDimensions]            `json:"dimensions"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
 }
 
 // BoxDimensions is the width, depth, and height of a box
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/BoxDimensions
 type BoxDimensions struct {
+	Interpolatable
 	Cartesian *Cartesian3Value `json:"cartesian,omitempty"`
 	Reference ReferenceValue   `json:"reference,omitempty"`
 }
@@ -49,146 +53,153 @@ type CornerType struct {
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CornerTypeValue
 type CornerTypeValue string
 
+const (
+	CornerTypeRounded CornerTypeValue = "ROUNDED"
+	CornerTypeMitered CornerTypeValue = "MITERED"
+	CornerTypeBeveled CornerTypeValue = "BEVELED"
+)
+
 // Corridor is a shape defined by a centerline and width that conforms to the curvature of the
 // globe. It can be placed on the surface or at altitude and can optionally be extruded *into a
 // volume.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Corridor
 type Corridor struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Positions                *PositionList             `json:"positions,omitempty"`
-	Width                    *float64                  `json:"width,omitempty"`
-	Height                   *float64                  `json:"height,omitempty"`
-	HeightReference          *HeightReference          `json:"heightReference,omitempty"`
-	ExtrudedHeight           *float64                  `json:"extrudedHeight,omitempty"`
-	ExtrudedHeightReference  *HeightReference          `json:"extrudedHeightReference,omitempty"`
-	CornerType               *CornerType               `json:"cornerType,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	ClassificationType       ClassificationType        `json:"classificationType,omitempty"`
-	ZIndex                   *int                      `json:"zIndex,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Positions                *Property[PositionList]             `json:"positions,omitempty"`
+	Width                    *Property[Double]                   `json:"width,omitempty"`
+	Height                   *Property[Double]                   `json:"height,omitempty"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference,omitempty"`
+	ExtrudedHeight           *Property[Double]                   `json:"extrudedHeight,omitempty"`
+	ExtrudedHeightReference  *Property[HeightReference]          `json:"extrudedHeightReference,omitempty"`
+	CornerType               *Property[CornerType]               `json:"cornerType,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	ClassificationType       *Property[ClassificationType]       `json:"classificationType,omitempty"`
+	ZIndex                   *Property[int]                      `json:"zIndex,omitempty"`
 }
 
 // Cylinder is a cylinder, truncated cone, or cone defined by a length, top radius, and bottom
 // radius.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Cylinder
 type Cylinder struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Length                   *float64                  `json:"length"`
-	TopRadius                *float64                  `json:"topRadius"`
-	BottomRadius             *float64                  `json:"bottomRadius"`
-	HeightReference          *HeightReference          `json:"heightReference,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	NumberOfVerticalLines    *int                      `json:"numberOfVerticalLines,omitempty"`
-	Slices                   *int                      `json:"slices,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Length                   *Property[Double]                   `json:"length"`
+	TopRadius                *Property[Double]                   `json:"topRadius"`
+	BottomRadius             *Property[Double]                   `json:"bottomRadius"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	NumberOfVerticalLines    *Property[int]                      `json:"numberOfVerticalLines,omitempty"`
+	Slices                   *Property[int]                      `json:"slices,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
 }
 
 // Ellipse is a closed curve on or above the surface of the Earth.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Ellipse
 type Ellipse struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	SemiMajorAxis            *float64                  `json:"semiMajorAxis"`
-	SemiMinorAxis            *float64                  `json:"semiMinorAxis"`
-	Height                   *float64                  `json:"height,omitempty"`
-	HeightReference          *HeightReference          `json:"heightReference,omitempty"`
-	ExtrudedHeight           *float64                  `json:"extrudedHeight,omitempty"`
-	ExtrudedHeightReference  *HeightReference          `json:"extrudedHeightReference,omitempty"`
-	Rotation                 *float64                  `json:"rotation,omitempty"`
-	StRotation               *float64                  `json:"stRotation,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	NumberOfVerticalLines    *int                      `json:"numberOfVerticalLines,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	ClassificationType       ClassificationType        `json:"classificationType,omitempty"`
-	ZIndex                   *int                      `json:"zIndex,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	SemiMajorAxis            *Property[Double]                   `json:"semiMajorAxis"`
+	SemiMinorAxis            *Property[Double]                   `json:"semiMinorAxis"`
+	Height                   *Property[Double]                   `json:"height,omitempty"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference,omitempty"`
+	ExtrudedHeight           *Property[Double]                   `json:"extrudedHeight,omitempty"`
+	ExtrudedHeightReference  *Property[HeightReference]          `json:"extrudedHeightReference,omitempty"`
+	Rotation                 *Property[Double]                   `json:"rotation,omitempty"`
+	StRotation               *Property[Double]                   `json:"stRotation,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	NumberOfVerticalLines    *Property[int]                      `json:"numberOfVerticalLines,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	ClassificationType       *Property[ClassificationType]       `json:"classificationType,omitempty"`
+	ZIndex                   *Property[int]                      `json:"zIndex,omitempty"`
 }
 
 // Ellipsoid is a closed quadric surface that is a three-dimensional analogue of an ellipse.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Ellipsoid
 type Ellipsoid struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Radii                    *EllipsoidRadii           `json:"radii"`
-	InnerRadii               *EllipsoidRadii           `json:"innerRadii,omitempty"`
-	MinimumClock             *float64                  `json:"minimumClock,omitempty"`
-	MaximumClock             *float64                  `json:"maximumClock,omitempty"`
-	MinimumCone              *float64                  `json:"minimumCone,omitempty"`
-	MaximumCone              *float64                  `json:"maximumCone,omitempty"`
-	HeightReference          *HeightReference          `json:"heightReference,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	StackPartitions          *int                      `json:"stackPartitions,omitempty"`
-	SlicePartitions          *int                      `json:"slicePartitions,omitempty"`
-	Subdivisions             *int                      `json:"subdivisions,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Radii                    *Property[EllipsoidRadii]           `json:"radii"`
+	InnerRadii               *Property[EllipsoidRadii]           `json:"innerRadii,omitempty"`
+	MinimumClock             *Property[Double]                   `json:"minimumClock,omitempty"`
+	MaximumClock             *Property[Double]                   `json:"maximumClock,omitempty"`
+	MinimumCone              *Property[Double]                   `json:"minimumCone,omitempty"`
+	MaximumCone              *Property[Double]                   `json:"maximumCone,omitempty"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	StackPartitions          *Property[int]                      `json:"stackPartitions,omitempty"`
+	SlicePartitions          *Property[int]                      `json:"slicePartitions,omitempty"`
+	Subdivisions             *Property[int]                      `json:"subdivisions,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
 }
 
 // EllipsoidRadii is the radii of an ellipsoid
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/EllipsoidRadii
 type EllipsoidRadii struct {
-	Cartesian Cartesian3Value `json:"cartesian,omitempty"`
-	Reference ReferenceValue  `json:"reference,omitempty"`
+	Interpolatable
+	Cartesian *Cartesian3Value `json:"cartesian,omitempty"`
+	Reference ReferenceValue   `json:"reference,omitempty"`
 }
 
 // Point is a viewport-aligned circle.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Point
 type Point struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	PixelSize                *float64                  `json:"pixelSize,omitempty"`
-	HeightReference          *HeightReference          `json:"heightReference"`
-	Color                    *Color                    `json:"color,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	ScaleByDistance          *NearFarScaler            `json:"scaleByDistance,omitempty"`
-	TranslucencyByDistance   *NearFarScaler            `json:"translucencyByDistance,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	DisableDepthTestDistance *float64                  `json:"disableDepthTestDistance,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	PixelSize                *Property[Double]                   `json:"pixelSize,omitempty"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference"`
+	Color                    *Property[Color]                    `json:"color,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	ScaleByDistance          *Property[NearFarScaler]            `json:"scaleByDistance,omitempty"`
+	TranslucencyByDistance   *Property[NearFarScaler]            `json:"translucencyByDistance,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	DisableDepthTestDistance *Property[Double]                   `json:"disableDepthTestDistance,omitempty"`
 }
 
 // Polygon is a closed figure on the surface of the Earth.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Polygon
 type Polygon struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Positions                *PositionList             `json:"positions,omitempty"`
-	Holes                    *PositionListOfLists      `json:"holes,omitempty"`
-	ArcType                  *ArcType                  `json:"arcType,omitempty"`
-	Height                   *float64                  `json:"height,omitempty"`
-	HeightReference          *HeightReference          `json:"heightReference,omitempty"`
-	ExtrudedHeight           *float64                  `json:"extrudedHeight,omitempty"`
-	ExtrudedHeightReference  *HeightReference          `json:"extrudedHeightReference,omitempty"`
-	StRotation               *float64                  `json:"stRotation,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	PerPositionHeight        *bool                     `json:"perPositionHeight,omitempty"`
-	CloseTop                 *bool                     `json:"closeTop,omitempty"`
-	CloseBottom              *bool                     `json:"closeBottom,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	ClassificationType       ClassificationType        `json:"classificationType,omitempty"`
-	ZIndex                   *int                      `json:"zIndex,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Positions                *Property[PositionList]             `json:"positions,omitempty"`
+	Holes                    *Property[PositionListOfLists]      `json:"holes,omitempty"`
+	ArcType                  *Property[ArcType]                  `json:"arcType,omitempty"`
+	Height                   *Property[Double]                   `json:"height,omitempty"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference,omitempty"`
+	ExtrudedHeight           *Property[Double]                   `json:"extrudedHeight,omitempty"`
+	ExtrudedHeightReference  *Property[HeightReference]          `json:"extrudedHeightReference,omitempty"`
+	StRotation               *Property[Double]                   `json:"stRotation,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	PerPositionHeight        *Property[bool]                     `json:"perPositionHeight,omitempty"`
+	CloseTop                 *Property[bool]                     `json:"closeTop,omitempty"`
+	CloseBottom              *Property[bool]                     `json:"closeBottom,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	ClassificationType       *Property[ClassificationType]       `json:"classificationType,omitempty"`
+	ZIndex                   *Property[int]                      `json:"zIndex,omitempty"`
 }
 
 // Shape is a list of two-dimensional positions defining a shape.
@@ -201,30 +212,31 @@ type Shape struct {
 // placed on the surface or at altitude and can optionally be extruded into a volume.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Rectangle
 type Rectangle struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Coordinates              *RectangleCoordinates     `json:"coordinates"`
-	Height                   *float64                  `json:"height,omitempty"`
-	HeightReference          *HeightReference          `json:"heightReference,omitempty"`
-	ExtrudedHeight           *float64                  `json:"extrudedHeight,omitempty"`
-	ExtrudedHeightReference  *HeightReference          `json:"extrudedHeightReference,omitempty"`
-	Rotation                 *float64                  `json:"rotation,omitempty"`
-	StRotation               *float64                  `json:"stRotation,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
-	ClassificationType       ClassificationType        `json:"classificationType,omitempty"`
-	ZIndex                   *int                      `json:"zIndex,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Coordinates              *Property[RectangleCoordinates]     `json:"coordinates"`
+	Height                   *Property[Double]                   `json:"height,omitempty"`
+	HeightReference          *Property[HeightReference]          `json:"heightReference,omitempty"`
+	ExtrudedHeight           *Property[Double]                   `json:"extrudedHeight,omitempty"`
+	ExtrudedHeightReference  *Property[HeightReference]          `json:"extrudedHeightReference,omitempty"`
+	Rotation                 *Property[Double]                   `json:"rotation,omitempty"`
+	StRotation               *Property[Double]                   `json:"stRotation,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
+	ClassificationType       *Property[ClassificationType]       `json:"classificationType,omitempty"`
+	ZIndex                   *Property[int]                      `json:"zIndex,omitempty"`
 }
 
 // RectangleCoordinates is a set of coordinates describing a cartographic rectangle on the surface
 // of the ellipsoid.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/RectangleCoordinates
 type RectangleCoordinates struct {
+	Interpolatable
 	Wsen        *CartographicRectangleRadiansValue `json:"wsen,omitempty"`
 	WsenDegrees *CartographicRectangleDegreesValue `json:"wsenDegrees,omitempty"`
 	Reference   ReferenceValue                     `json:"reference"`
@@ -233,62 +245,62 @@ type RectangleCoordinates struct {
 // Tileset is a 3D Tiles tileset
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Tileset
 type Tileset struct {
-	Show                    *bool    `json:"show,omitempty"`
-	Uri                     *Uri     `json:"uri"`
-	MaximumScreenSpaceError *float64 `json:"maximumScreenSpaceError,omitempty"`
+	Show                    *Property[bool]   `json:"show,omitempty"`
+	Uri                     *Property[Uri]    `json:"uri"`
+	MaximumScreenSpaceError *Property[Double] `json:"maximumScreenSpaceError,omitempty"`
 }
 
 // Wall is a  two-dimensional wall defined as a line strip and optional maximum and minimum heights,
 // which conforms to the curvature of the globe and can be placed along the surface or at altitude.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Wall
 type Wall struct {
-	Show                     *bool                     `json:"show,omitempty"`
-	Positions                *PositionList             `json:"positions"`
-	MinimumHeights           *DoubleList               `json:"minimumHeights,omitempty"`
-	MaximumHeights           *DoubleList               `json:"maximumHeights,omitempty"`
-	Granularity              *float64                  `json:"granularity,omitempty"`
-	Fill                     *bool                     `json:"fill,omitempty"`
-	Material                 *Material                 `json:"material,omitempty"`
-	Outline                  *bool                     `json:"outline,omitempty"`
-	OutlineColor             *Color                    `json:"outlineColor,omitempty"`
-	OutlineWidth             *float64                  `json:"outlineWidth,omitempty"`
-	Shadows                  ShadowMode                `json:"shadows,omitempty"`
-	DistanceDisplayCondition *DistanceDisplayCondition `json:"distanceDisplayCondition,omitempty"`
+	Show                     *Property[bool]                     `json:"show,omitempty"`
+	Positions                *Property[PositionList]             `json:"positions"`
+	MinimumHeights           *Property[DoubleList]               `json:"minimumHeights,omitempty"`
+	MaximumHeights           *Property[DoubleList]               `json:"maximumHeights,omitempty"`
+	Granularity              *Property[Double]                   `json:"granularity,omitempty"`
+	Fill                     *Property[bool]                     `json:"fill,omitempty"`
+	Material                 *Property[Material]                 `json:"material,omitempty"`
+	Outline                  *Property[bool]                     `json:"outline,omitempty"`
+	OutlineColor             *Property[Color]                    `json:"outlineColor,omitempty"`
+	OutlineWidth             *Property[Double]                   `json:"outlineWidth,omitempty"`
+	Shadows                  *Property[ShadowMode]               `json:"shadows,omitempty"`
+	DistanceDisplayCondition *Property[DistanceDisplayCondition] `json:"distanceDisplayCondition,omitempty"`
 }
 
 // ConicSensor is a conical sensor volume taking into account occlusion of an ellipsoid, i.e.,
 // the globe.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/ConicSensor
 type ConicSensor struct {
-	Show                            *bool                         `json:"show,omitempty"`
-	InnerHalfAngle                  *float64                      `json:"innerHalfAngle,omitempty"`
-	OuterHalfAngle                  *float64                      `json:"outerHalfAngle,omitempty"`
-	MinimumClockAngle               *float64                      `json:"minimumClockAngle,omitempty"`
-	MaximumClockAngle               *float64                      `json:"maximumClockAngle,omitempty"`
-	Radius                          *float64                      `json:"radius,omitempty"`
-	ShowIntersection                *bool                         `json:"showIntersection,omitempty"`
-	IntersectionColor               *Color                        `json:"intersectionColor,omitempty"`
-	IntersectionWidth               *float64                      `json:"intersectionWidth,omitempty"`
-	ShowLateralSurfaces             *bool                         `json:"showLateralSurfaces,omitempty"`
-	LateralSurfaceMaterial          *Material                     `json:"lateralSurfaceMaterial,omitempty"`
-	ShowEllipsoidSurfaces           *bool                         `json:"showEllipsoidSurfaces,omitempty"`
-	EllipsoidSurfaceMaterial        *Material                     `json:"ellipsoidSurfaceMaterial,omitempty"`
-	ShowEllipsoidHorizonSurfaces    *bool                         `json:"showEllipsoidHorizonSurfaces,omitempty"`
-	EllipsoidHorizonSurfaceMaterial *Material                     `json:"ellipsoidHorizonSurfaceMaterial,omitempty"`
-	ShowDomeSurfaces                *bool                         `json:"showDomeSurfaces,omitempty"`
-	DomeSurfaceMaterial             *Material                     `json:"domeSurfaceMaterial,omitempty"`
-	PortionToDisplay                *SensorVolumePortionToDisplay `json:"portionToDisplay"`
-	EnvironmentConstraint           *bool                         `json:"environmentConstraint,omitempty"`
-	ShowEnvironmentOcclusion        *bool                         `json:"showEnvironmentOcclusion,omitempty"`
-	EnvironmentOcclusionMaterial    *Material                     `json:"environmentOcclusionMaterial,omitempty"`
-	ShowEnvironmentIntersection     *bool                         `json:"showEnvironmentIntersection,omitempty"`
-	EnvironmentIntersectionColor    *Color                        `json:"environmentIntersectionColor,omitempty"`
-	EnvironmentIntersectionWidth    *float64                      `json:"environmentIntersectionWidth,omitempty"`
-	ShowThroughEllipsoid            *bool                         `json:"showThroughEllipsoid,omitempty"`
-	ShowViewshed                    *bool                         `json:"showViewshed,omitempty"`
-	ViewshedVisibleColor            *Color                        `json:"viewshedVisibleColor,omitempty"`
-	ViewshedOccludedColor           *Color                        `json:"viewshedOccludedColor,omitempty"`
-	ViewshedResolution              *int                          `json:"viewshedResolution,omitempty"`
+	Show                            *Property[bool]                         `json:"show,omitempty"`
+	InnerHalfAngle                  *Property[Double]                       `json:"innerHalfAngle,omitempty"`
+	OuterHalfAngle                  *Property[Double]                       `json:"outerHalfAngle,omitempty"`
+	MinimumClockAngle               *Property[Double]                       `json:"minimumClockAngle,omitempty"`
+	MaximumClockAngle               *Property[Double]                       `json:"maximumClockAngle,omitempty"`
+	Radius                          *Property[Double]                       `json:"radius,omitempty"`
+	ShowIntersection                *Property[bool]                         `json:"showIntersection,omitempty"`
+	IntersectionColor               *Property[Color]                        `json:"intersectionColor,omitempty"`
+	IntersectionWidth               *Property[Double]                       `json:"intersectionWidth,omitempty"`
+	ShowLateralSurfaces             *Property[bool]                         `json:"showLateralSurfaces,omitempty"`
+	LateralSurfaceMaterial          *Property[Material]                     `json:"lateralSurfaceMaterial,omitempty"`
+	ShowEllipsoidSurfaces           *Property[bool]                         `json:"showEllipsoidSurfaces,omitempty"`
+	EllipsoidSurfaceMaterial        *Property[Material]                     `json:"ellipsoidSurfaceMaterial,omitempty"`
+	ShowEllipsoidHorizonSurfaces    *Property[bool]                         `json:"showEllipsoidHorizonSurfaces,omitempty"`
+	EllipsoidHorizonSurfaceMaterial *Property[Material]                     `json:"ellipsoidHorizonSurfaceMaterial,omitempty"`
+	ShowDomeSurfaces                *Property[bool]                         `json:"showDomeSurfaces,omitempty"`
+	DomeSurfaceMaterial             *Property[Material]                     `json:"domeSurfaceMaterial,omitempty"`
+	PortionToDisplay                *Property[SensorVolumePortionToDisplay] `json:"portionToDisplay"`
+	EnvironmentConstraint           *Property[bool]                         `json:"environmentConstraint,omitempty"`
+	ShowEnvironmentOcclusion        *Property[bool]                         `json:"showEnvironmentOcclusion,omitempty"`
+	EnvironmentOcclusionMaterial    *Property[Material]                     `json:"environmentOcclusionMaterial,omitempty"`
+	ShowEnvironmentIntersection     *Property[bool]                         `json:"showEnvironmentIntersection,omitempty"`
+	EnvironmentIntersectionColor    *Property[Color]                        `json:"environmentIntersectionColor,omitempty"`
+	EnvironmentIntersectionWidth    *Property[Double]                       `json:"environmentIntersectionWidth,omitempty"`
+	ShowThroughEllipsoid            *Property[bool]                         `json:"showThroughEllipsoid,omitempty"`
+	ShowViewshed                    *Property[bool]                         `json:"showViewshed,omitempty"`
+	ViewshedVisibleColor            *Property[Color]                        `json:"viewshedVisibleColor,omitempty"`
+	ViewshedOccludedColor           *Property[Color]                        `json:"viewshedOccludedColor,omitempty"`
+	ViewshedResolution              *Property[int]                          `json:"viewshedResolution,omitempty"`
 }
 
 // SensorVolumePortionToDisplay is the part of a sensor that should be displayed
@@ -296,96 +308,102 @@ type ConicSensor struct {
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/SensorVolumePortionToDisplay
 type SensorVolumePortionToDisplay string
 
+const (
+	PortionToDisplayComplete              SensorVolumePortionToDisplay = "COMPLETE"
+	PortionToDisplayBelowEllipsoidHorizon SensorVolumePortionToDisplay = "BELOW_ELLIPSOID_HORIZON"
+	PortionToDisplayAboveEllipsoidHorizon SensorVolumePortionToDisplay = "ABOVE_ELLIPSOID_HORIZON"
+)
+
 // CustomPatternSensor is a custom sensor volume taking into account occlusion of an ellipsoid,
 // i.e., the globe.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CustomPatternSensor
 type CustomPatternSensor struct {
-	Show                            *bool                         `json:"show,omitempty"`
-	Directions                      *DirectionList                `json:"directions"`
-	Radius                          *float64                      `json:"radius,omitempty"`
-	ShowIntersection                *bool                         `json:"showIntersection,omitempty"`
-	IntersectionColor               *Color                        `json:"intersectionColor,omitempty"`
-	IntersectionWidth               *float64                      `json:"intersectionWidth,omitempty"`
-	ShowLateralSurfaces             *bool                         `json:"showLateralSurfaces,omitempty"`
-	LateralSurfaceMaterial          *Material                     `json:"lateralSurfaceMaterial,omitempty"`
-	ShowEllipsoidSurfaces           *bool                         `json:"showEllipsoidSurfaces,omitempty"`
-	EllipsoidSurfaceMaterial        *Material                     `json:"ellipsoidSurfaceMaterial,omitempty"`
-	ShowEllipsoidHorizonSurfaces    *bool                         `json:"showEllipsoidHorizonSurfaces,omitempty"`
-	EllipsoidHorizonSurfaceMaterial *Material                     `json:"ellipsoidHorizonSurfaceMaterial,omitempty"`
-	ShowDomeSurfaces                *bool                         `json:"showDomeSurfaces,omitempty"`
-	DomeSurfaceMaterial             *Material                     `json:"domeSurfaceMaterial,omitempty"`
-	PortionToDisplay                *SensorVolumePortionToDisplay `json:"portionToDisplay"`
-	EnvironmentConstraint           *bool                         `json:"environmentConstraint,omitempty"`
-	ShowEnvironmentOcclusion        *bool                         `json:"showEnvironmentOcclusion,omitempty"`
-	EnvironmentOcclusionMaterial    *Material                     `json:"environmentOcclusionMaterial,omitempty"`
-	ShowEnvironmentIntersection     *bool                         `json:"showEnvironmentIntersection,omitempty"`
-	EnvironmentIntersectionColor    *Color                        `json:"environmentIntersectionColor,omitempty"`
-	EnvironmentIntersectionWidth    *float64                      `json:"environmentIntersectionWidth,omitempty"`
-	ShowThroughEllipsoid            *bool                         `json:"showThroughEllipsoid,omitempty"`
-	ShowViewshed                    *bool                         `json:"showViewshed,omitempty"`
-	ViewshedVisibleColor            *Color                        `json:"viewshedVisibleColor,omitempty"`
-	ViewshedOccludedColor           *Color                        `json:"viewshedOccludedColor,omitempty"`
-	ViewshedResolution              *int                          `json:"viewshedResolution,omitempty"`
+	Show                            *Property[bool]                         `json:"show,omitempty"`
+	Directions                      *Property[DirectionList]                `json:"directions"`
+	Radius                          *Property[Double]                       `json:"radius,omitempty"`
+	ShowIntersection                *Property[bool]                         `json:"showIntersection,omitempty"`
+	IntersectionColor               *Property[Color]                        `json:"intersectionColor,omitempty"`
+	IntersectionWidth               *Property[Double]                       `json:"intersectionWidth,omitempty"`
+	ShowLateralSurfaces             *Property[bool]                         `json:"showLateralSurfaces,omitempty"`
+	LateralSurfaceMaterial          *Property[Material]                     `json:"lateralSurfaceMaterial,omitempty"`
+	ShowEllipsoidSurfaces           *Property[bool]                         `json:"showEllipsoidSurfaces,omitempty"`
+	EllipsoidSurfaceMaterial        *Property[Material]                     `json:"ellipsoidSurfaceMaterial,omitempty"`
+	ShowEllipsoidHorizonSurfaces    *Property[bool]                         `json:"showEllipsoidHorizonSurfaces,omitempty"`
+	EllipsoidHorizonSurfaceMaterial *Property[Material]                     `json:"ellipsoidHorizonSurfaceMaterial,omitempty"`
+	ShowDomeSurfaces                *Property[bool]                         `json:"showDomeSurfaces,omitempty"`
+	DomeSurfaceMaterial             *Property[Material]                     `json:"domeSurfaceMaterial,omitempty"`
+	PortionToDisplay                *Property[SensorVolumePortionToDisplay] `json:"portionToDisplay"`
+	EnvironmentConstraint           *Property[bool]                         `json:"environmentConstraint,omitempty"`
+	ShowEnvironmentOcclusion        *Property[bool]                         `json:"showEnvironmentOcclusion,omitempty"`
+	EnvironmentOcclusionMaterial    *Property[Material]                     `json:"environmentOcclusionMaterial,omitempty"`
+	ShowEnvironmentIntersection     *Property[bool]                         `json:"showEnvironmentIntersection,omitempty"`
+	EnvironmentIntersectionColor    *Property[Color]                        `json:"environmentIntersectionColor,omitempty"`
+	EnvironmentIntersectionWidth    *Property[Double]                       `json:"environmentIntersectionWidth,omitempty"`
+	ShowThroughEllipsoid            *Property[bool]                         `json:"showThroughEllipsoid,omitempty"`
+	ShowViewshed                    *Property[bool]                         `json:"showViewshed,omitempty"`
+	ViewshedVisibleColor            *Property[Color]                        `json:"viewshedVisibleColor,omitempty"`
+	ViewshedOccludedColor           *Property[Color]                        `json:"viewshedOccludedColor,omitempty"`
+	ViewshedResolution              *Property[int]                          `json:"viewshedResolution,omitempty"`
 }
 
 // RectangularSensor is a rectangular pyramid sensor volume taking into account occlusion of an
 // ellipsoid, i.e., the globe.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/RectangularSensor
 type RectangularSensor struct {
-	Show                            *bool                         `json:"show,omitempty"`
-	XHalfAngle                      *float64                      `json:"xHalfAngle,omitempty"`
-	YHalfAngle                      *float64                      `json:"yHalfAngle,omitempty"`
-	Radius                          *float64                      `json:"radius,omitempty"`
-	ShowIntersection                *bool                         `json:"showIntersection,omitempty"`
-	IntersectionColor               *Color                        `json:"intersectionColor,omitempty"`
-	IntersectionWidth               *float64                      `json:"intersectionWidth,omitempty"`
-	ShowLateralSurfaces             *bool                         `json:"showLateralSurfaces,omitempty"`
-	LateralSurfaceMaterial          *Material                     `json:"lateralSurfaceMaterial,omitempty"`
-	ShowEllipsoidSurfaces           *bool                         `json:"showEllipsoidSurfaces,omitempty"`
-	EllipsoidSurfaceMaterial        *Material                     `json:"ellipsoidSurfaceMaterial,omitempty"`
-	ShowEllipsoidHorizonSurfaces    *bool                         `json:"showEllipsoidHorizonSurfaces,omitempty"`
-	EllipsoidHorizonSurfaceMaterial *Material                     `json:"ellipsoidHorizonSurfaceMaterial,omitempty"`
-	ShowDomeSurfaces                *bool                         `json:"showDomeSurfaces,omitempty"`
-	DomeSurfaceMaterial             *Material                     `json:"domeSurfaceMaterial,omitempty"`
-	PortionToDisplay                *SensorVolumePortionToDisplay `json:"portionToDisplay"`
-	EnvironmentConstraint           *bool                         `json:"environmentConstraint,omitempty"`
-	ShowEnvironmentOcclusion        *bool                         `json:"showEnvironmentOcclusion,omitempty"`
-	EnvironmentOcclusionMaterial    *Material                     `json:"environmentOcclusionMaterial,omitempty"`
-	ShowEnvironmentIntersection     *bool                         `json:"showEnvironmentIntersection,omitempty"`
-	EnvironmentIntersectionColor    *Color                        `json:"environmentIntersectionColor,omitempty"`
-	EnvironmentIntersectionWidth    *float64                      `json:"environmentIntersectionWidth,omitempty"`
-	ShowThroughEllipsoid            *bool                         `json:"showThroughEllipsoid,omitempty"`
-	ShowViewshed                    *bool                         `json:"showViewshed,omitempty"`
-	ViewshedVisibleColor            *Color                        `json:"viewshedVisibleColor,omitempty"`
-	ViewshedOccludedColor           *Color                        `json:"viewshedOccludedColor,omitempty"`
-	ViewshedResolution              *int                          `json:"viewshedResolution,omitempty"`
+	Show                            *Property[bool]                         `json:"show,omitempty"`
+	XHalfAngle                      *Property[Double]                       `json:"xHalfAngle,omitempty"`
+	YHalfAngle                      *Property[Double]                       `json:"yHalfAngle,omitempty"`
+	Radius                          *Property[Double]                       `json:"radius,omitempty"`
+	ShowIntersection                *Property[bool]                         `json:"showIntersection,omitempty"`
+	IntersectionColor               *Property[Color]                        `json:"intersectionColor,omitempty"`
+	IntersectionWidth               *Property[Double]                       `json:"intersectionWidth,omitempty"`
+	ShowLateralSurfaces             *Property[bool]                         `json:"showLateralSurfaces,omitempty"`
+	LateralSurfaceMaterial          *Property[Material]                     `json:"lateralSurfaceMaterial,omitempty"`
+	ShowEllipsoidSurfaces           *Property[bool]                         `json:"showEllipsoidSurfaces,omitempty"`
+	EllipsoidSurfaceMaterial        *Property[Material]                     `json:"ellipsoidSurfaceMaterial,omitempty"`
+	ShowEllipsoidHorizonSurfaces    *Property[bool]                         `json:"showEllipsoidHorizonSurfaces,omitempty"`
+	EllipsoidHorizonSurfaceMaterial *Property[Material]                     `json:"ellipsoidHorizonSurfaceMaterial,omitempty"`
+	ShowDomeSurfaces                *Property[bool]                         `json:"showDomeSurfaces,omitempty"`
+	DomeSurfaceMaterial             *Property[Material]                     `json:"domeSurfaceMaterial,omitempty"`
+	PortionToDisplay                *Property[SensorVolumePortionToDisplay] `json:"portionToDisplay"`
+	EnvironmentConstraint           *Property[bool]                         `json:"environmentConstraint,omitempty"`
+	ShowEnvironmentOcclusion        *Property[bool]                         `json:"showEnvironmentOcclusion,omitempty"`
+	EnvironmentOcclusionMaterial    *Property[Material]                     `json:"environmentOcclusionMaterial,omitempty"`
+	ShowEnvironmentIntersection     *Property[bool]                         `json:"showEnvironmentIntersection,omitempty"`
+	EnvironmentIntersectionColor    *Property[Color]                        `json:"environmentIntersectionColor,omitempty"`
+	EnvironmentIntersectionWidth    *Property[Double]                       `json:"environmentIntersectionWidth,omitempty"`
+	ShowThroughEllipsoid            *Property[bool]                         `json:"showThroughEllipsoid,omitempty"`
+	ShowViewshed                    *Property[bool]                         `json:"showViewshed,omitempty"`
+	ViewshedVisibleColor            *Property[Color]                        `json:"viewshedVisibleColor,omitempty"`
+	ViewshedOccludedColor           *Property[Color]                        `json:"viewshedOccludedColor,omitempty"`
+	ViewshedResolution              *Property[int]                          `json:"viewshedResolution,omitempty"`
 }
 
 // Fan starts at a point or apex and extends in a specified list of directions from the apex. Each
 // pair of directions forms a face of the fan extending to the specified radius.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Fan
 type Fan struct {
-	Show               *bool          `json:"show,omitempty"`
-	Directions         *DirectionList `json:"directions"`
-	Radius             *float64       `json:"radius,omitempty"`
-	PerDirectionRadius *bool          `json:"perDirectionRadius,omitempty"`
-	Material           *Material      `json:"material,omitempty"`
-	Fill               *bool          `json:"fill,omitempty"`
-	Outline            *bool          `json:"outline,omitempty"`
-	OutlineColor       *Color         `json:"outlineColor,omitempty"`
-	OutlineWidth       *float64       `json:"outlineWidth,omitempty"`
-	NumberOfRings      *int           `json:"numberOfRings,omitempty"`
+	Show               *Property[bool]          `json:"show,omitempty"`
+	Directions         *Property[DirectionList] `json:"directions"`
+	Radius             *Property[Double]        `json:"radius,omitempty"`
+	PerDirectionRadius *Property[bool]          `json:"perDirectionRadius,omitempty"`
+	Material           *Property[Material]      `json:"material,omitempty"`
+	Fill               *Property[bool]          `json:"fill,omitempty"`
+	Outline            *Property[bool]          `json:"outline,omitempty"`
+	OutlineColor       *Property[Color]         `json:"outlineColor,omitempty"`
+	OutlineWidth       *Property[Double]        `json:"outlineWidth,omitempty"`
+	NumberOfRings      *Property[int]           `json:"numberOfRings,omitempty"`
 }
 
 // Vector defines a graphical vector that originates at the position property and extends in the
 // provided direction for the provided length.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Vector
 type Vector struct {
-	Show                  *bool      `json:"show,omitempty"`
-	Color                 *Color     `json:"color,omitempty"`
-	Direction             *Direction `json:"direction"`
-	Length                *float64   `json:"length,omitempty"`
-	MinimumLengthInPixels *float64   `json:"minimumLengthInPixels,omitempty"`
+	Show                  *Property[bool]      `json:"show,omitempty"`
+	Color                 *Property[Color]     `json:"color,omitempty"`
+	Direction             *Property[Direction] `json:"direction"`
+	Length                *Property[Double]    `json:"length,omitempty"`
+	MinimumLengthInPixels *Property[Double]    `json:"minimumLengthInPixels,omitempty"`
 }
 
 // Spherical is a spherical value [Clock, Cone, Magnitude], with angles in radians and magnitude in
@@ -395,14 +413,17 @@ type Vector struct {
 // time-tagged samples arranged as [Time, Clock, Cone, Magnitude, Time, Clock, Cone, Magnitude, ...]
 // where Time is an ISO 8601 date and time string or seconds since epoch.
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/SphericalValue
-type SphericalValue []interface{}
+type SphericalValue struct {
+	SampledValue
+}
 
 // Direction is a unit vector, in world coordinates, that defines a direction
 // https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/Direction
 type Direction struct {
-	Spherical           *SphericalValue     `json:"spherical,omitempty"`
-	UnitSpherical       *UnitSphericalValue `json:"unitSpherical,omitempty"`
-	Cartesian           *Cartesian3Value    `json:"cartesian,omitempty"`
-	UnitCartesian3Value `json:"unitCartesian,omitempty"`
-	Reference           ReferenceValue `json:"reference,omitempty"`
+	Interpolatable
+	Spherical     *SphericalValue      `json:"spherical,omitempty"`
+	UnitSpherical *UnitSphericalValue  `json:"unitSpherical,omitempty"`
+	Cartesian     *Cartesian3Value     `json:"cartesian,omitempty"`
+	UnitCartesian *UnitCartesian3Value `json:"unitCartesian,omitempty"`
+	Reference     ReferenceValue       `json:"reference,omitempty"`
 }
@@ -0,0 +1,384 @@
+package czml
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// SimplifyAlgorithm is the algorithm used to remove positions from a line
+type SimplifyAlgorithm string
+
+const (
+	// SimplifyDouglasPeucker keeps every position further than the tolerance from the simplified line
+	SimplifyDouglasPeucker SimplifyAlgorithm = "DOUGLAS_PEUCKER"
+
+	// SimplifyVisvalingam removes positions in the order of the area of their triangle with their
+	// neighbours, smallest first, which keeps the overall shape of smooth lines. A position is only
+	// removed if every position removed so far stays within the tolerance of the simplified line.
+	SimplifyVisvalingam SimplifyAlgorithm = "VISVALINGAM"
+)
+
+// Simplify returns a copy of the list with positions removed, keeping the first and last. Distances
+// are measured in meters between Earth-fixed positions, including heights. The copy uses the
+// representation of the list.
+func (p *PositionList) Simplify(algorithm SimplifyAlgorithm, tolerance float64) (*PositionList, error) {
+	representation, err := p.representation()
+	if err != nil {
+		return nil, err
+	}
+	fixed, err := p.As(RepresentationCartesian)
+	if err != nil {
+		return nil, err
+	}
+
+	list := *fixed.Cartesian
+	points := make([]cartesian, len(list)/3)
+	for i := range points {
+		points[i] = cartesian{list[3*i], list[3*i+1], list[3*i+2]}
+	}
+
+	keep, err := simplify(algorithm, points, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	// the kept positions are taken from the list, so they are not changed by conversions
+	var kept []float64
+	original := p.values(representation)
+	for i, k := range keep {
+		if k {
+			kept = append(kept, original[3*i:3*i+3]...)
+		}
+	}
+
+	result := &PositionList{ReferenceFrame: p.ReferenceFrame}
+	switch representation {
+	case RepresentationCartesian:
+		cartesian := Cartesian3ListValue(kept)
+		result.Cartesian = &cartesian
+	case RepresentationCartographicRadians:
+		radians := CartographicRadiansListValue(kept)
+		result.CartographicRadians = &radians
+	default:
+		result.CartographicDegrees = kept
+	}
+
+	return result, nil
+}
+
+// values returns the list in one of the representations it is given in
+func (p *PositionList) values(r Representation) []float64 {
+	switch r {
+	case RepresentationCartesian:
+		return *p.Cartesian
+	case RepresentationCartographicRadians:
+		return *p.CartographicRadians
+	}
+
+	return p.CartographicDegrees
+}
+
+// Simplify returns a copy of the position with samples removed from the path they trace, keeping
+// the first and last samples and the times of the others. Distances are measured in meters between
+// Earth-fixed positions, including heights, and times are ignored. Constant positions are returned
+// unchanged.
+func (p *Position) Simplify(algorithm SimplifyAlgorithm, tolerance float64) (*Position, error) {
+	samples, _, points, err := p.simplificationSamples()
+	if err != nil {
+		return nil, err
+	}
+
+	keep, err := simplify(algorithm, points, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.withSamples(samples, keep), nil
+}
+
+// SimplifyInTime returns a copy of the position with samples removed, keeping the first and last.
+// Unlike Simplify, it compares each sample with the position interpolated at its time rather than
+// with the path, so a sample is only removed if the position of the simplified property at its time
+// stays within tolerance meters of it. This keeps stops and changes of speed along straight paths.
+// Positions interpolated with LAGRANGE or HERMITE are checked by evaluating them, which is slower.
+func (p *Position) SimplifyInTime(tolerance float64) (*Position, error) {
+	if tolerance < 0 {
+		return nil, errors.New("czml: tolerance must not be negative")
+	}
+	samples, times, points, err := p.simplificationSamples()
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) < 3 {
+		return p.withSamples(samples, allKept(len(samples))), nil
+	}
+
+	// synchronous Euclidean distance: the distance to the position at the same time on the line
+	// between the kept samples
+	keep := douglasPeucker(points, tolerance, func(i, first, last int) float64 {
+		span := times[last].Sub(times[first]).Seconds()
+		if span == 0 {
+			return points[i].sub(points[first]).length()
+		}
+		f := times[i].Sub(times[first]).Seconds() / span
+		at := points[first].add(points[last].sub(points[first]).scale(f))
+		return points[i].sub(at).length()
+	})
+
+	if p.InterpolationAlgorithm == "" || p.InterpolationAlgorithm == InterpolationLinear {
+		return p.withSamples(samples, keep), nil
+	}
+
+	// other algorithms curve between samples, so the samples furthest from the simplified
+	// property are restored until every sample is within tolerance
+	for {
+		simplified := p.withSamples(samples, keep)
+		restored := false
+
+		for first := 0; first < len(keep)-1; {
+			last := first + 1
+			for !keep[last] {
+				last++
+			}
+
+			worst, distance := -1, tolerance
+			for i := first + 1; i < last; i++ {
+				at, err := simplified.cartesianAt(times[i])
+				if err != nil {
+					return nil, err
+				}
+				if d := points[i].sub(at).length(); d > distance {
+					worst, distance = i, d
+				}
+			}
+			if worst >= 0 {
+				keep[worst], restored = true, true
+			}
+			first = last
+		}
+
+		if !restored {
+			return simplified, nil
+		}
+	}
+}
+
+// simplificationSamples returns the samples of the position in time order, their times, and their
+// Earth-fixed positions
+func (p *Position) simplificationSamples() ([]Sample, []time.Time, []cartesian, error) {
+	if p.ReferenceFrame == "INERTIAL" {
+		return nil, nil, nil, errors.New("czml: positions in the INERTIAL reference frame cannot be simplified")
+	}
+	v, ok := p.sampledValue()
+	if !ok {
+		return nil, nil, nil, errors.New("czml: position has no value to simplify")
+	}
+
+	samples, times, err := p.sortedSamples(v)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	points := make([]cartesian, len(samples))
+	for i, s := range samples {
+		if len(s.Value) < 3 {
+			return nil, nil, nil, fmt.Errorf("czml: sample %d has %d values", i, len(s.Value))
+		}
+		points[i] = p.cartesianOf(s.Value)
+	}
+
+	return samples, times, points, nil
+}
+
+// withSamples returns a copy of the position holding the kept samples in its representation
+func (p *Position) withSamples(samples []Sample, keep []bool) *Position {
+	result := deepCopy(reflect.ValueOf(p)).Interface().(*Position)
+	v, ok := result.sampledValue()
+	if !ok || !v.IsSampled() {
+		return result
+	}
+
+	kept := SampledValue{}
+	for i, s := range samples {
+		if keep[i] {
+			kept.Samples = append(kept.Samples, deepCopy(reflect.ValueOf(s)).Interface().(Sample))
+		}
+	}
+
+	switch {
+	case result.CartesianVelocity != nil:
+		result.CartesianVelocity.SampledValue = kept
+	case result.Cartesian != nil:
+		result.Cartesian.SampledValue = kept
+	case result.CartographicRadians != nil:
+		result.CartographicRadians.SampledValue = kept
+	case result.CartographicDegrees != nil:
+		result.CartographicDegrees.SampledValue = kept
+	}
+
+	return result
+}
+
+// simplify returns which points the algorithm keeps
+func simplify(algorithm SimplifyAlgorithm, points []cartesian, tolerance float64) ([]bool, error) {
+	if tolerance < 0 {
+		return nil, errors.New("czml: tolerance must not be negative")
+	}
+	if len(points) < 3 {
+		return allKept(len(points)), nil
+	}
+
+	switch algorithm {
+	case SimplifyDouglasPeucker:
+		return douglasPeucker(points, tolerance, func(i, first, last int) float64 {
+			return segmentDistance(points[i], points[first], points[last])
+		}), nil
+	case SimplifyVisvalingam:
+		return visvalingam(points, tolerance), nil
+	}
+
+	return nil, fmt.Errorf("czml: unknown simplification algorithm %q", algorithm)
+}
+
+func allKept(n int) []bool {
+	keep := make([]bool, n)
+	for i := range keep {
+		keep[i] = true
+	}
+
+	return keep
+}
+
+// douglasPeucker keeps the first and last points, and then the point furthest from the line
+// between each pair of kept points, for as long as it is further than the tolerance
+func douglasPeucker(points []cartesian, tolerance float64, distance func(i, first, last int) float64) []bool {
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	// an explicit stack, as long tracks would recurse too deeply
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		worst, furthest := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := distance(i, first, last); d > furthest {
+				worst, furthest = i, d
+			}
+		}
+		if worst >= 0 {
+			keep[worst] = true
+			stack = append(stack, [2]int{first, worst}, [2]int{worst, last})
+		}
+	}
+
+	return keep
+}
+
+// segmentDistance returns the distance from p to the segment from a to b
+func segmentDistance(p, a, b cartesian) float64 {
+	ab := b.sub(a)
+	length := ab.dot(ab)
+	if length == 0 {
+		return p.sub(a).length()
+	}
+
+	f := math.Max(0, math.Min(1, p.sub(a).dot(ab)/length))
+	return p.sub(a.add(ab.scale(f))).length()
+}
+
+// visvalingam removes points in the order of their effective area, the area of their triangle with
+// their neighbours, smallest first. A point is kept instead if removing it would leave one of the
+// points between its neighbours further than the tolerance from the line joining them.
+func visvalingam(points []cartesian, tolerance float64) []bool {
+	keep := allKept(len(points))
+	previous := make([]int, len(points))
+	next := make([]int, len(points))
+	triangles := make([]*triangle, len(points))
+	h := &triangleHeap{}
+
+	for i := 1; i < len(points)-1; i++ {
+		previous[i], next[i] = i-1, i+1
+		triangles[i] = &triangle{point: i, area: triangleArea(points[i-1], points[i], points[i+1])}
+		heap.Push(h, triangles[i])
+	}
+
+	fixed := make([]bool, len(points))
+	for h.Len() > 0 {
+		t := heap.Pop(h).(*triangle)
+		p, n := previous[t.point], next[t.point]
+		if !withinTolerance(points, p, n, tolerance) {
+			fixed[t.point] = true
+			continue
+		}
+
+		keep[t.point] = false
+		next[p], previous[n] = n, p
+
+		// neighbours never get a smaller area than the point removed before them, so that points
+		// are removed in the order of their significance
+		for _, neighbour := range []int{p, n} {
+			if neighbour == 0 || neighbour == len(points)-1 || fixed[neighbour] {
+				continue
+			}
+			nt := triangles[neighbour]
+			nt.area = math.Max(t.area,
+				triangleArea(points[previous[neighbour]], points[neighbour], points[next[neighbour]]))
+			heap.Fix(h, nt.index)
+		}
+	}
+
+	return keep
+}
+
+// withinTolerance reports whether every point between first and last is within the tolerance of
+// the segment joining them
+func withinTolerance(points []cartesian, first, last int, tolerance float64) bool {
+	for i := first + 1; i < last; i++ {
+		if segmentDistance(points[i], points[first], points[last]) > tolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+func triangleArea(a, b, c cartesian) float64 {
+	return b.sub(a).cross(c.sub(a)).length() / 2
+}
+
+// triangle is a point and the area of its triangle with its neighbours
+type triangle struct {
+	point int
+	area  float64
+	index int
+}
+
+// triangleHeap is a heap of triangles with the smallest area first
+type triangleHeap []*triangle
+
+func (h triangleHeap) Len() int           { return len(h) }
+func (h triangleHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+
+func (h triangleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *triangleHeap) Push(x interface{}) {
+	t := x.(*triangle)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *triangleHeap) Pop() interface{} {
+	old := *h
+	t := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return t
+}
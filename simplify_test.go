@@ -0,0 +1,152 @@
+package czml
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// planar returns a list of Earth-fixed positions on a plane tangent to the equator, from east and
+// north offsets in meters
+func planar(offsets ...[2]float64) *PositionList {
+	list := Cartesian3ListValue{}
+	for _, o := range offsets {
+		list = append(list, wgs84A, o[0], o[1])
+	}
+
+	return &PositionList{Cartesian: &list}
+}
+
+func points(l *PositionList) []cartesian {
+	list := *l.Cartesian
+	result := make([]cartesian, len(list)/3)
+	for i := range result {
+		result[i] = cartesian{list[3*i], list[3*i+1], list[3*i+2]}
+	}
+
+	return result
+}
+
+func TestSimplify(t *testing.T) {
+	wave := make([][2]float64, 50)
+	for i := range wave {
+		x := float64(i) * 10
+		wave[i] = [2]float64{x, 30*math.Sin(x/40) + 5*math.Sin(x/7)}
+	}
+	// positions 1 m apart with a spike of 30 m, whose triangle is small next to its neighbours. The
+	// positions at the foot of the spike are more than 10 m from the lines to its top, so they stay.
+	spike := make([][2]float64, 41)
+	for i := range spike {
+		spike[i] = [2]float64{float64(i), 0}
+	}
+	spike[20][1] = 30
+
+	tests := []struct {
+		name      string
+		algorithm SimplifyAlgorithm
+		line      [][2]float64
+		tolerance float64
+		want      int // the number of positions kept, or -1 to only check the tolerance
+	}{
+		{"straight line", SimplifyDouglasPeucker, [][2]float64{{0, 0}, {1, 1}, {2, 2}, {3, 3}}, 0.01, 2},
+		// the positions beside the spike are within the tolerance of the lines to its top
+		{"spike above the tolerance", SimplifyDouglasPeucker, [][2]float64{{0, 0}, {1, 0}, {2, 5}, {3, 0}, {4, 0}}, 1, 3},
+		{"spike below the tolerance", SimplifyDouglasPeucker, [][2]float64{{0, 0}, {1, 0}, {2, 0.5}, {3, 0}, {4, 0}}, 1, 2},
+		{"square corners", SimplifyDouglasPeucker, [][2]float64{{0, 0}, {5, 0}, {10, 0}, {10, 5}, {10, 10}}, 0.1, 3},
+		{"zero tolerance keeps bends", SimplifyDouglasPeucker, [][2]float64{{0, 0}, {1, 0}, {2, 0}, {3, 0.001}}, 0, 3},
+		{"two positions", SimplifyDouglasPeucker, [][2]float64{{0, 0}, {1, 1}}, 10, 2},
+		{"douglas-peucker wave", SimplifyDouglasPeucker, wave, 2, -1},
+		{"visvalingam straight line", SimplifyVisvalingam, [][2]float64{{0, 0}, {1, 1}, {2, 2}, {3, 3}}, 0.01, 2},
+		{"visvalingam spike", SimplifyVisvalingam, [][2]float64{{0, 0}, {1, 0}, {2, 5}, {3, 0}, {4, 0}}, 3, 3},
+		{"visvalingam small spike", SimplifyVisvalingam, [][2]float64{{0, 0}, {1, 0}, {2, 0.5}, {3, 0}, {4, 0}}, 1, 2},
+		{"visvalingam narrow spike", SimplifyVisvalingam, spike, 10, 5},
+		{"visvalingam wave", SimplifyVisvalingam, wave, 10, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := planar(tt.line...)
+			simplified, err := list.Simplify(tt.algorithm, tt.tolerance)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			original, kept := points(list), points(simplified)
+			if tt.want >= 0 && len(kept) != tt.want {
+				t.Errorf("kept %d positions, want %d", len(kept), tt.want)
+			}
+			if kept[0] != original[0] || kept[len(kept)-1] != original[len(original)-1] {
+				t.Error("the first and last positions were not kept")
+			}
+
+			// every position is within the tolerance of the simplified line
+			for i, p := range original {
+				nearest := math.Inf(1)
+				for j := 1; j < len(kept); j++ {
+					nearest = math.Min(nearest, segmentDistance(p, kept[j-1], kept[j]))
+				}
+				if nearest > tt.tolerance+1e-9 {
+					t.Errorf("position %d is %.3f m from the simplified line", i, nearest)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplifyInTime(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := func(s float64) time.Time { return start.Add(time.Duration(s * float64(time.Second))) }
+
+	// a straight track that stops and then speeds up
+	stopping := []float64{0, 10, 20, 30, 30, 30, 60, 90}
+	// a curved track
+	curve := make([]float64, 40)
+	for i := range curve {
+		curve[i] = 100 * math.Sin(float64(i)/6)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm InterpolationAlgorithm
+		east      []float64
+		north     func(i int) float64
+		tolerance float64
+		want      int // the number of samples kept, or -1 to only check the tolerance
+	}{
+		{"constant speed", InterpolationLinear, []float64{0, 10, 20, 30, 40}, func(int) float64 { return 0 }, 0.1, 2},
+		{"stop and speed up", InterpolationLinear, stopping, func(int) float64 { return 0 }, 0.1, 4},
+		{"linear curve", InterpolationLinear, curve, func(i int) float64 { return float64(i) * 5 }, 1, -1},
+		{"lagrange curve", InterpolationLagrange, curve, func(i int) float64 { return float64(i) * 5 }, 1, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Position{Cartesian: &Cartesian3Value{}}
+			if tt.algorithm != InterpolationLinear {
+				p.SetInterpolation(tt.algorithm, 5)
+			}
+			for i, e := range tt.east {
+				p.Cartesian.AddSample(DateTag(second(float64(i))), wgs84A, e, tt.north(i))
+			}
+
+			simplified, err := p.SimplifyInTime(tt.tolerance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(simplified.Cartesian.Samples); tt.want >= 0 && n != tt.want {
+				t.Errorf("kept %d samples, want %d", n, tt.want)
+			}
+
+			// the simplified position is within the tolerance of every sample at its time
+			for i, e := range tt.east {
+				at, err := simplified.cartesianAt(second(float64(i)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d := at.sub(cartesian{wgs84A, e, tt.north(i)}).length(); d > tt.tolerance+1e-9 {
+					t.Errorf("sample %d is %.3f m from the simplified position", i, d)
+				}
+			}
+		})
+	}
+}
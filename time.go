@@ -0,0 +1,343 @@
+package czml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sentinel strings CZML uses for the open ends of intervals
+const (
+	minimumTimeString = "0000-00-00T00:00:00Z"
+	maximumTimeString = "9999-12-31T24:00:00Z"
+)
+
+var (
+	// MinimumTime is the earliest representable time, written as "0000-00-00T00:00:00Z"
+	MinimumTime = JulianDate{time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)}
+
+	// MaximumTime is the latest representable time, written as "9999-12-31T24:00:00Z"
+	MaximumTime = JulianDate{time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC)}
+
+	// Forever is the interval covering all time
+	Forever = TimeInterval{Start: MinimumTime, Stop: MaximumTime}
+)
+
+// iso8601Layouts are the ISO 8601 forms accepted when reading times, in order of preference. Times
+// without a zone are UTC, as in Cesium.
+var iso8601Layouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"20060102T150405.999999999Z07:00",
+	"20060102T150405.999999999",
+	"20060102T1504Z07:00",
+	"2006-01-02",
+	"20060102",
+}
+
+// JulianDate is an instant in time. It is written in CZML as an ISO 8601 date and time string in
+// UTC.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/CZML-Structure#iso-8601-dates-and-times
+type JulianDate struct {
+	time.Time
+}
+
+// NewJulianDate returns the JulianDate of a time.Time
+func NewJulianDate(t time.Time) JulianDate {
+	return JulianDate{t.UTC()}
+}
+
+// ParseJulianDate reads an ISO 8601 date and time string, including the sentinels CZML uses for
+// the open ends of intervals
+func ParseJulianDate(s string) (JulianDate, error) {
+	switch s {
+	case minimumTimeString:
+		return MinimumTime, nil
+	case maximumTimeString:
+		return MaximumTime, nil
+	}
+
+	// ISO 8601 allows 24:00:00 as the end of a day
+	endOfDay := false
+	for _, midnight := range []string{"T24:00:00", "T240000", "T24:00"} {
+		if i := strings.Index(s, midnight); i > 0 {
+			s = s[:i] + "T00" + s[i+3:]
+			endOfDay = true
+			break
+		}
+	}
+
+	for _, layout := range iso8601Layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			if endOfDay {
+				t = t.AddDate(0, 0, 1)
+			}
+			return NewJulianDate(t), nil
+		}
+	}
+
+	return JulianDate{}, fmt.Errorf("czml: %q is not an ISO 8601 date and time", s)
+}
+
+// String formats the date as an ISO 8601 string in UTC
+func (d JulianDate) String() string {
+	switch {
+	case !d.After(MinimumTime.Time):
+		return minimumTimeString
+	case !d.Before(MaximumTime.Time):
+		return maximumTimeString
+	}
+
+	return d.UTC().Format(time.RFC3339Nano)
+}
+
+// Compare returns -1, 0 or 1 when d is before, equal to or after o
+func (d JulianDate) Compare(o JulianDate) int {
+	switch {
+	case d.Before(o.Time):
+		return -1
+	case d.After(o.Time):
+		return 1
+	}
+
+	return 0
+}
+
+// AddSeconds returns the date a number of elapsed seconds after d, counting any leap seconds in
+// between
+func (d JulianDate) AddSeconds(s float64) JulianDate {
+	return NewJulianDateIn(d.As(TimeStandardTAI).Add(seconds(s)), TimeStandardTAI)
+}
+
+// SecondsSince returns the number of elapsed seconds from o to d, counting any leap seconds in
+// between
+func (d JulianDate) SecondsSince(o JulianDate) float64 {
+	return secondsBetween(o.Time, d.Time)
+}
+
+func (d JulianDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *JulianDate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseJulianDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// TimeInterval is a closed interval of time, written in CZML as an ISO 8601 interval "start/stop"
+type TimeInterval struct {
+	Start JulianDate
+	Stop  JulianDate
+}
+
+// NewTimeInterval returns the interval between two times
+func NewTimeInterval(start, stop time.Time) TimeInterval {
+	return TimeInterval{Start: NewJulianDate(start), Stop: NewJulianDate(stop)}
+}
+
+// ParseTimeInterval reads an ISO 8601 interval written as "start/stop"
+func ParseTimeInterval(s string) (TimeInterval, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return TimeInterval{}, fmt.Errorf("czml: %q is not an ISO 8601 interval", s)
+	}
+
+	start, err := ParseJulianDate(parts[0])
+	if err != nil {
+		return TimeInterval{}, err
+	}
+	stop, err := ParseJulianDate(parts[1])
+	if err != nil {
+		return TimeInterval{}, err
+	}
+
+	return TimeInterval{Start: start, Stop: stop}, nil
+}
+
+func (i TimeInterval) String() string {
+	return i.Start.String() + "/" + i.Stop.String()
+}
+
+// IsEmpty reports whether the interval stops before it starts
+func (i TimeInterval) IsEmpty() bool {
+	return i.Stop.Before(i.Start.Time)
+}
+
+// Contains reports whether the interval contains a time, including its start and stop
+func (i TimeInterval) Contains(t time.Time) bool {
+	return !i.IsEmpty() && !t.Before(i.Start.Time) && !t.After(i.Stop.Time)
+}
+
+// Intersect returns the part of time common to both intervals, or false if they do not overlap
+func (i TimeInterval) Intersect(o TimeInterval) (TimeInterval, bool) {
+	result := i
+	if o.Start.After(result.Start.Time) {
+		result.Start = o.Start
+	}
+	if o.Stop.Before(result.Stop.Time) {
+		result.Stop = o.Stop
+	}
+
+	return result, !result.IsEmpty() && !i.IsEmpty() && !o.IsEmpty()
+}
+
+func (i TimeInterval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+func (i *TimeInterval) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseTimeInterval(s)
+	if err != nil {
+		return err
+	}
+
+	*i = parsed
+	return nil
+}
+
+// TimeIntervalCollection is a list of time intervals. It is written as a single interval string
+// when it holds one interval, and as an array of interval strings otherwise.
+// https://github.com/AnalyticalGraphicsInc/czml-writer/wiki/TimeIntervalCollection
+type TimeIntervalCollection []TimeInterval
+
+// Normalize returns the intervals of the collection sorted by start, with empty intervals removed
+// and overlapping or touching intervals merged
+func (c TimeIntervalCollection) Normalize() TimeIntervalCollection {
+	result := TimeIntervalCollection{}
+	for _, i := range c {
+		if !i.IsEmpty() {
+			result = append(result, i)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Start.Before(result[b].Start.Time) })
+
+	merged := TimeIntervalCollection{}
+	for _, i := range result {
+		last := len(merged) - 1
+		if last >= 0 && !i.Start.After(merged[last].Stop.Time) {
+			if i.Stop.After(merged[last].Stop.Time) {
+				merged[last].Stop = i.Stop
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+
+	return merged
+}
+
+// Union returns the time covered by either collection
+func (c TimeIntervalCollection) Union(o TimeIntervalCollection) TimeIntervalCollection {
+	all := append(TimeIntervalCollection{}, c...)
+	return append(all, o...).Normalize()
+}
+
+// Intersection returns the time covered by both collections
+func (c TimeIntervalCollection) Intersection(o TimeIntervalCollection) TimeIntervalCollection {
+	result := TimeIntervalCollection{}
+	for _, a := range c.Normalize() {
+		for _, b := range o.Normalize() {
+			if i, ok := a.Intersect(b); ok {
+				result = append(result, i)
+			}
+		}
+	}
+
+	return result.Normalize()
+}
+
+// Contains reports whether any interval of the collection contains a time
+func (c TimeIntervalCollection) Contains(t time.Time) bool {
+	for _, i := range c {
+		if i.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Each calls fn for every interval of the normalized collection in order, until fn returns false
+func (c TimeIntervalCollection) Each(fn func(TimeInterval) bool) {
+	for _, i := range c.Normalize() {
+		if !fn(i) {
+			return
+		}
+	}
+}
+
+func (c TimeIntervalCollection) MarshalJSON() ([]byte, error) {
+	if len(c) == 1 {
+		return json.Marshal(c[0])
+	}
+
+	return json.Marshal([]TimeInterval(c))
+}
+
+func (c *TimeIntervalCollection) UnmarshalJSON(data []byte) error {
+	var single TimeInterval
+	if err := json.Unmarshal(data, &single); err == nil {
+		*c = TimeIntervalCollection{single}
+		return nil
+	}
+
+	var list []TimeInterval
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*c = list
+	return nil
+}
+
+// Resolve returns the absolute time of the tag. Tags given in seconds are relative to epoch, which
+// must then be set.
+func (t TimeTag) Resolve(epoch *JulianDate) (time.Time, error) {
+	if t.IsDate() {
+		return t.Date.Time, nil
+	}
+	if epoch == nil {
+		return time.Time{}, errors.New("czml: sample time is given in seconds but the property has no epoch")
+	}
+
+	return epoch.AddSeconds(t.Seconds).Time, nil
+}
+
+// seconds converts a number of seconds to a time.Duration, rounded to the nearest nanosecond
+func seconds(s float64) time.Duration {
+	return time.Duration(s*float64(time.Second) + 0.5*sign(s))
+}
+
+// secondsBetween returns the number of elapsed seconds from a to b, counting any leap seconds in
+// between
+func secondsBetween(a, b time.Time) float64 {
+	return b.Sub(a).Seconds() + float64(LeapSeconds(b)-LeapSeconds(a))
+}
+
+func sign(f float64) float64 {
+	if f < 0 {
+		return -1
+	}
+
+	return 1
+}
@@ -0,0 +1,124 @@
+package czml
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJulianDateSentinels(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  JulianDate
+		text  string // the date written back
+	}{
+		{"minimum", "0000-00-00T00:00:00Z", MinimumTime, "0000-00-00T00:00:00Z"},
+		{"maximum", "9999-12-31T24:00:00Z", MaximumTime, "9999-12-31T24:00:00Z"},
+		{"end of a day", "2020-01-01T24:00:00Z", NewJulianDate(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)), "2020-01-02T00:00:00Z"},
+		{"basic form", "20200101T123000Z", NewJulianDate(time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC)), "2020-01-01T12:30:00Z"},
+		{"zone", "2020-01-01T12:30:00+02:00", NewJulianDate(time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC)), "2020-01-01T10:30:00Z"},
+		{"date alone", "2020-01-01", NewJulianDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), "2020-01-01T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJulianDate(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want.Time) {
+				t.Errorf("date is %v, want %v", got.Time, tt.want.Time)
+			}
+			if got.String() != tt.text {
+				t.Errorf("date is written as %s, want %s", got, tt.text)
+			}
+		})
+	}
+
+	if _, err := ParseJulianDate("yesterday"); err == nil {
+		t.Error("read a date from \"yesterday\"")
+	}
+}
+
+// intervals returns a collection of intervals given as pairs of hours on 2020-01-01
+func intervals(hours ...int) TimeIntervalCollection {
+	c := TimeIntervalCollection{}
+	for i := 0; i < len(hours); i += 2 {
+		c = append(c, NewTimeInterval(time.Date(2020, 1, 1, hours[i], 0, 0, 0, time.UTC),
+			time.Date(2020, 1, 1, hours[i+1], 0, 0, 0, time.UTC)))
+	}
+
+	return c
+}
+
+func TestTimeIntervalCollection(t *testing.T) {
+	tests := []struct {
+		name string
+		got  TimeIntervalCollection
+		want TimeIntervalCollection
+	}{
+		{"normalize sorts", intervals(4, 5, 1, 2).Normalize(), intervals(1, 2, 4, 5)},
+		{"normalize merges overlaps", intervals(1, 3, 2, 4).Normalize(), intervals(1, 4)},
+		{"normalize merges touching intervals", intervals(1, 2, 2, 3).Normalize(), intervals(1, 3)},
+		{"normalize keeps contained intervals within", intervals(1, 5, 2, 3).Normalize(), intervals(1, 5)},
+		{"normalize drops empty intervals", intervals(3, 2, 4, 5).Normalize(), intervals(4, 5)},
+		{"union", intervals(1, 2).Union(intervals(3, 4, 2, 3)), intervals(1, 4)},
+		{"union of disjoint intervals", intervals(1, 2).Union(intervals(3, 4)), intervals(1, 2, 3, 4)},
+		{"union with nothing", intervals(1, 2).Union(intervals()), intervals(1, 2)},
+		{"intersection", intervals(1, 3, 4, 6).Intersection(intervals(2, 5)), intervals(2, 3, 4, 5)},
+		{"intersection at a point", intervals(1, 2).Intersection(intervals(2, 3)), intervals(2, 2)},
+		{"intersection of disjoint intervals", intervals(1, 2).Intersection(intervals(3, 4)), intervals()},
+		{"intersection with forever", intervals(1, 2).Intersection(TimeIntervalCollection{Forever}), intervals(1, 2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.got) != len(tt.want) {
+				t.Fatalf("collection is %v, want %v", tt.got, tt.want)
+			}
+			for i := range tt.got {
+				if tt.got[i].String() != tt.want[i].String() {
+					t.Errorf("collection is %v, want %v", tt.got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeIntervalCollectionJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want TimeIntervalCollection
+	}{
+		{"single interval", `"2020-01-01T01:00:00Z/2020-01-01T02:00:00Z"`, intervals(1, 2)},
+		{"list", `["2020-01-01T01:00:00Z/2020-01-01T02:00:00Z","2020-01-01T03:00:00Z/2020-01-01T04:00:00Z"]`, intervals(1, 2, 3, 4)},
+		{"forever", `"0000-00-00T00:00:00Z/9999-12-31T24:00:00Z"`, TimeIntervalCollection{Forever}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c TimeIntervalCollection
+			if err := json.Unmarshal([]byte(tt.data), &c); err != nil {
+				t.Fatal(err)
+			}
+			if len(c) != len(tt.want) {
+				t.Fatalf("read %v, want %v", c, tt.want)
+			}
+			for i := range c {
+				if c[i].String() != tt.want[i].String() {
+					t.Errorf("read %v, want %v", c, tt.want)
+				}
+			}
+
+			data, err := json.Marshal(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.data {
+				t.Errorf("written as %s, want %s", data, tt.data)
+			}
+		})
+	}
+}
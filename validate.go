@@ -0,0 +1,236 @@
+package czml
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ValidationError describes a part of a .czml document that Cesium would reject or ignore
+type ValidationError struct {
+	Path    string // JSON-path-like location, e.g. `packets[12].polyline.material.solidColor.color.rgba`
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// validator is implemented by values that can check their own contents. validate returns an empty
+// string when the value is valid.
+type validator interface {
+	validate() string
+}
+
+// Validate checks the .czml document against the rules of the CZML spec that can be checked
+// without a client, and returns every problem found. It returns nil for a valid document. Packets
+// sharing an id are updates, which are merged as a Scene does, and an update that leaves its
+// entity as the earlier packets made it is reported.
+func (c *Czml) Validate() []ValidationError {
+	var errs []ValidationError
+	add := func(path, format string, a ...interface{}) {
+		errs = append(errs, ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if len(c.Packets) == 0 || c.Packets[0].Id != "document" {
+		add("packets[0]", "first packet must be the document packet")
+	} else if c.Packets[0].Version == "" {
+		add("packets[0].version", "document packet must set a version")
+	}
+
+	ids := map[string]int{}
+	for i, p := range c.Packets {
+		if p.Id != "" {
+			if _, ok := ids[p.Id]; !ok {
+				ids[p.Id] = i
+			}
+		}
+	}
+
+	resolver := NewResolver(*c)
+	scene := NewScene()
+
+	for i, p := range c.Packets {
+		path := fmt.Sprintf("packets[%d]", i)
+
+		if p.Id == "document" && i > 0 {
+			add(path+".id", "document packet must appear once, as the first packet")
+		} else if unchanged(scene, p) {
+			add(path+".id", "packet updates %q without changing it", p.Id)
+		}
+
+		if p.Parent != "" {
+			if _, ok := ids[p.Parent]; !ok {
+				add(path+".parent", "parent %q does not exist", p.Parent)
+			} else if p.Parent == p.Id {
+				add(path+".parent", "packet cannot be its own parent")
+			}
+		}
+
+		walk(path, reflect.ValueOf(p), func(path string, v reflect.Value) bool {
+			sampled, isSampled := v.Interface().(sampledType)
+			if isSampled {
+				if msg := sampled.values().check(sampled.dimension()); msg != "" {
+					add(path, "%s", msg)
+				}
+			}
+			if val, ok := v.Interface().(validator); ok {
+				if msg := val.validate(); msg != "" {
+					add(path, "%s", msg)
+				}
+			}
+			if ref, ok := referenceOf(v); ok && v.Kind() == reflect.String && ref != "" {
+				if _, err := resolver.Resolve(p.Id, ref); err != nil {
+					add(path, "%v", err)
+				}
+			}
+			return !isSampled
+		})
+	}
+
+	for _, cycle := range c.Hierarchy().Cycles() {
+		if len(cycle) > 1 {
+			add(fmt.Sprintf("packets[%d].parent", c.lastParent(cycle[0])), "parents form a cycle: %s", cycleString(cycle))
+		}
+	}
+
+	return errs
+}
+
+// lastParent returns the index of the last packet with the provided id that sets a parent
+func (c *Czml) lastParent(id string) int {
+	for i := len(c.Packets) - 1; i >= 0; i-- {
+		if c.Packets[i].Id == id && c.Packets[i].Parent != "" {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unchanged applies a packet to the scene of the packets before it, and reports whether it updates
+// an entity without changing it. Packets with a repeated id are intentional updates, but an update
+// that changes nothing is a mistake.
+func unchanged(scene *Scene, p Packet) bool {
+	if p.Id == "" || p.Id == "document" {
+		return false
+	}
+
+	before, ok := scene.Entity(p.Id)
+	if err := scene.Apply(p); err != nil || !ok {
+		return false
+	}
+	after, _ := scene.Entity(p.Id)
+
+	return reflect.DeepEqual(before, after)
+}
+
+// checkList checks the length of an array holding a list of values of the provided dimension
+func checkList(length, dimension int) string {
+	if length%dimension == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("length %d is not a multiple of %d", length, dimension)
+}
+
+// checkEnum checks that a string-valued property holds one of its legal values. An empty value is
+// treated as unset.
+func checkEnum(value string, legal ...string) string {
+	if value == "" || isLegal(value, legal) {
+		return ""
+	}
+
+	return fmt.Sprintf("%q is not one of %s", value, strings.Join(legal, ", "))
+}
+
+func (v Cartesian3ListValue) validate() string {
+	return checkList(len(v), 3)
+}
+
+func (v CartographicRadiansListValue) validate() string {
+	return checkList(len(v), 3)
+}
+
+func (v RgbaValue) validate() string {
+	components := [][]float64{v.Value}
+	for _, s := range v.Samples {
+		components = append(components, s.Value)
+	}
+
+	for _, value := range components {
+		for _, c := range value {
+			if c < 0 || c > 255 || c != float64(int(c)) {
+				return fmt.Sprintf("component value %v is not an integer in the range 0-255", c)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (v CartographicDegreesListValue) validate() string {
+	return checkList(len(v), 3)
+}
+
+func (v ArcTypeValue) validate() string {
+	return checkEnum(string(v), arcTypes...)
+}
+
+func (m ShadowMode) validate() string {
+	return checkEnum(string(m), shadowModes...)
+}
+
+func (t ClassificationType) validate() string {
+	return checkEnum(string(t), classificationTypes...)
+}
+
+func (v HeightReferenceValue) validate() string {
+	return checkEnum(string(v), heightReferences...)
+}
+
+func (v HorizontalOriginValue) validate() string {
+	return checkEnum(string(v), horizontalOrigins...)
+}
+
+func (v VerticalOriginValue) validate() string {
+	return checkEnum(string(v), verticalOrigins...)
+}
+
+func (v LabelStyleValue) validate() string {
+	return checkEnum(string(v), labelStyles...)
+}
+
+func (v ColorBlendModeValue) validate() string {
+	return checkEnum(string(v), colorBlendModes...)
+}
+
+func (v CornerTypeValue) validate() string {
+	return checkEnum(string(v), cornerTypes...)
+}
+
+func (o StripeOrientation) validate() string {
+	return checkEnum(string(o), stripeOrientations...)
+}
+
+func (p SensorVolumePortionToDisplay) validate() string {
+	return checkEnum(string(p), portionsToDisplay...)
+}
+
+func (a InterpolationAlgorithm) validate() string {
+	return checkEnum(string(a), interpolationAlgorithms...)
+}
+
+func (t ExtrapolationType) validate() string {
+	return checkEnum(string(t), extrapolationTypes...)
+}
+
+func (p Property[T]) validate() string {
+	for i, iv := range p.Intervals {
+		if iv.Interval.IsEmpty() {
+			return fmt.Sprintf("interval %d: %s stops before it starts", i, iv.Interval)
+		}
+	}
+
+	return ""
+}
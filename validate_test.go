@@ -0,0 +1,101 @@
+package czml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet string
+		want   string // the start of the only error
+	}{
+		{"single value",
+			`{"id": "e", "billboard": {"color": {"rgba": [300, 0, 0, 255]}}}`,
+			"packets[1].billboard.color.rgba: component value 300"},
+		{"interval value",
+			`{"id": "e", "billboard": {"color": [{"interval": "2020-01-01T00:00:00Z/2020-01-01T01:00:00Z", "rgba": [0, 0, 0, 255]}, {"interval": "2020-01-01T01:00:00Z/2020-01-01T02:00:00Z", "rgba": [0, 0, 0.5, 255]}]}}`,
+			"packets[1].billboard.color[1].rgba: component value 0.5"},
+		{"reference",
+			`{"id": "e", "label": {"text": {"reference": "missing#label.text"}}}`,
+			"packets[1].label.text.reference: czml: broken reference"},
+		{"interval reference",
+			`{"id": "e", "label": {"show": [{"interval": "2020-01-01T00:00:00Z/2020-01-01T01:00:00Z", "reference": "missing#label.show"}]}}`,
+			"packets[1].label.show[0].reference: czml: broken reference"},
+		{"empty interval",
+			`{"id": "e", "label": {"show": [{"interval": "2020-01-01T01:00:00Z/2020-01-01T00:00:00Z", "boolean": true}]}}`,
+			"packets[1].label.show: interval 0: "},
+		{"list within a property",
+			`{"id": "e", "polyline": {"positions": {"cartographicDegrees": [1, 2, 3, 4]}}}`,
+			"packets[1].polyline.positions.cartographicDegrees: length 4 is not a multiple of 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Czml{Packets: append([]Packet{{Id: "document", Version: "1.0"}}, parsePackets(t, []string{tt.packet})...)}
+
+			errs := doc.Validate()
+			if len(errs) != 1 {
+				t.Fatalf("found %d errors, want 1: %v", len(errs), errs)
+			}
+			if !strings.HasPrefix(errs[0].Error(), tt.want) {
+				t.Errorf("error is %q, want it to start with %q", errs[0].Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRepeats(t *testing.T) {
+	tests := []struct {
+		name    string
+		packets []string
+		want    []string // the errors found
+	}{
+		{"identical packet", []string{
+			`{"id": "e", "label": {"text": "a"}}`,
+			`{"id": "e", "label": {"text": "a"}}`,
+		}, []string{`packets[2].id: packet updates "e" without changing it`}},
+		{"update", []string{
+			`{"id": "e", "label": {"text": "a"}}`,
+			`{"id": "e", "label": {"text": "b"}}`,
+		}, nil},
+		{"change back", []string{
+			`{"id": "e", "label": {"text": "a"}}`,
+			`{"id": "e", "label": {"text": "b"}}`,
+			`{"id": "e", "label": {"text": "a"}}`,
+		}, nil},
+		{"values set by several packets", []string{
+			`{"id": "e", "label": {"text": "a"}}`,
+			`{"id": "e", "label": {"scale": 2}}`,
+			`{"id": "e", "label": {"text": "a", "scale": 2}}`,
+		}, []string{`packets[3].id: packet updates "e" without changing it`}},
+		{"samples already added", []string{
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3, "2020-01-01T00:01:00Z", 4, 5, 6]}}`,
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:01:00Z", 4, 5, 6]}}`,
+		}, []string{`packets[2].id: packet updates "e" without changing it`}},
+		{"new samples", []string{
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:00:00Z", 1, 2, 3]}}`,
+			`{"id": "e", "position": {"cartesian": ["2020-01-01T00:01:00Z", 4, 5, 6]}}`,
+		}, nil},
+		{"deleted and added again", []string{
+			`{"id": "e", "label": {"text": "a"}}`,
+			`{"id": "e", "delete": true}`,
+			`{"id": "e", "label": {"text": "a"}}`,
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Czml{Packets: append([]Packet{{Id: "document", Version: "1.0"}}, parsePackets(t, tt.packets)...)}
+
+			var got []string
+			for _, e := range doc.Validate() {
+				got = append(got, e.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("found %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
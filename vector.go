@@ -0,0 +1,54 @@
+package czml
+
+import "math"
+
+// cartesian is an Earth-fixed vector in meters, or a direction
+type cartesian [3]float64
+
+func (a cartesian) add(b cartesian) cartesian {
+	return cartesian{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func (a cartesian) sub(b cartesian) cartesian {
+	return cartesian{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func (a cartesian) scale(s float64) cartesian {
+	return cartesian{a[0] * s, a[1] * s, a[2] * s}
+}
+
+func (a cartesian) dot(b cartesian) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func (a cartesian) cross(b cartesian) cartesian {
+	return cartesian{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func (a cartesian) length() float64 {
+	return math.Sqrt(a.dot(a))
+}
+
+// normalize returns a scaled to unit length, or a itself if it has no length
+func (a cartesian) normalize() cartesian {
+	l := a.length()
+	if l == 0 {
+		return a
+	}
+
+	return a.scale(1 / l)
+}
+
+// geodeticNormal returns the unit vector normal to the WGS84 ellipsoid at the latitude and
+// longitude of an Earth-fixed position
+func geodeticNormal(position cartesian) cartesian {
+	lon, lat, _ := CartesianToCartographic(position)
+	sinLat, cosLat := math.Sincos(lat)
+	sinLon, cosLon := math.Sincos(lon)
+
+	return cartesian{cosLat * cosLon, cosLat * sinLon, sinLat}
+}
@@ -0,0 +1,175 @@
+package czml
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// visitor is called by walk for every value that is set, along with its JSON path. Returning false
+// skips the children of the value.
+type visitor func(path string, v reflect.Value) bool
+
+// walk visits v and every value reachable from it through exported fields, slices and maps, skipping
+// nil values. Paths are built from JSON names, e.g. `packets[12].polyline.material.solidColor.color.rgba`.
+// The single value of a Property shares its path, and its intervals are indexed like an array.
+func walk(path string, v reflect.Value, visit visitor) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return
+	}
+
+	if !visit(path, v) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if reflect.PtrTo(v.Type()).Implements(propertyValuerType) {
+			walkProperty(path, v, visit)
+			return
+		}
+		walkFields(path, v, visit)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if isContainer(v.Index(i)) {
+				walk(fmt.Sprintf("%s[%d]", path, i), v.Index(i), visit)
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walk(joinPath(path, fmt.Sprint(iter.Key().Interface())), iter.Value(), visit)
+		}
+	}
+}
+
+// walkFields visits the fields of a struct. Embedded structs without a JSON name are flattened into
+// their parent, the same way encoding/json treats them.
+func walkFields(path string, v reflect.Value, visit visitor) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				walkFields(path, fv, visit)
+				continue
+			}
+			name = f.Name
+		}
+
+		walk(joinPath(path, name), v.Field(i), visit)
+	}
+}
+
+// walkProperty visits the fields of a Property or IntervalValue, which have no JSON names of their
+// own. The value is visited at the path of the property, unless the property holds intervals.
+func walkProperty(path string, v reflect.Value, visit visitor) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		switch name := t.Field(i).Name; name {
+		case "Value":
+			if intervals := v.FieldByName("Intervals"); !intervals.IsValid() || intervals.Len() == 0 {
+				walk(path, v.Field(i), visit)
+			}
+		case "Intervals":
+			for j := 0; j < v.Field(i).Len(); j++ {
+				walk(fmt.Sprintf("%s[%d]", path, j), v.Field(i).Index(j), visit)
+			}
+		default:
+			walk(joinPath(path, strings.ToLower(name[:1])+name[1:]), v.Field(i), visit)
+		}
+	}
+}
+
+var propertyValuerType = reflect.TypeOf((*propertyValuer)(nil)).Elem()
+
+// fieldByJSONName returns the field of a struct with the provided JSON name, looking into embedded
+// structs the same way walkFields does
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		fieldName, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+
+		if f.Anonymous && fieldName == "" {
+			fv := reflect.Indirect(v.Field(i))
+			if fv.Kind() == reflect.Struct {
+				if found, ok := fieldByJSONName(fv, name); ok {
+					return found, true
+				}
+				continue
+			}
+			fieldName = f.Name
+		}
+
+		if fieldName == name {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// jsonName returns the JSON name of a struct field, or false if the field is not encoded. An
+// embedded field without a name in its tag returns an empty name.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name == "" && !f.Anonymous {
+		name = f.Name
+	}
+
+	return name, true
+}
+
+// isContainer reports whether a slice element can hold values worth visiting
+func isContainer(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
+		return true
+	}
+
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}